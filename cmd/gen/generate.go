@@ -44,7 +44,8 @@ func main() {
 
 	// 从连接的数据库为所有表生成Model结构体和CRUD代码
 	// 也可以手动指定需要生成代码的数据表
-	g.ApplyBasic(g.GenerateAllTable()...)
+	// 密码字段不参与json序列化，避免写入缓存和事件消息
+	g.ApplyBasic(g.GenerateAllTable(gen.FieldJSONTag("password", "-"))...)
 
 	// 执行并生成代码
 	g.Execute()
@@ -11,7 +11,7 @@ import (
 //
 //	@Summary	发送消息
 //	@Produce	json
-//	@Param		messageReq	body		req.MessageReq	true	"消息请求体"
+//	@Param		messageReq	body		req.MessageReq	true	"消息请求体，sendAt 为未来时间时保存为定时消息"
 //	@Success	200	{object}	resp.ResponseData	"成功"
 //	@Failure	500	{object}	resp.ResponseData	"内部错误"
 //	@Router		/api/chat/msg [post]
@@ -31,3 +31,304 @@ func SendMessageController(c *gin.Context) {
 	}
 	resp.ReturnSuccessResponse(c, response)
 }
+
+// EditMessageController 编辑消息
+//
+//	@Summary	编辑消息
+//	@Produce	json
+//	@Param		msgId	body		int64				true	"消息ID"
+//	@Param		content	body		string				true	"编辑后的内容"
+//	@Success	200		{object}	resp.ResponseData	"成功"
+//	@Failure	500		{object}	resp.ResponseData	"内部错误"
+//	@Router		/api/chat/msg [put]
+func EditMessageController(c *gin.Context) {
+	uid := c.GetInt64("uid")
+	editReq := req.EditMessageReq{}
+	if err := c.ShouldBind(&editReq); err != nil {
+		resp.ErrorResponse(c, "参数错误")
+		c.Abort()
+		return
+	}
+	response, err := service.EditMsgService(uid, editReq)
+	if err != nil {
+		c.Abort()
+		resp.ReturnErrorResponse(c, response)
+		return
+	}
+	resp.ReturnSuccessResponse(c, response)
+}
+
+// ReactionController 添加或取消表情回应
+//
+//	@Summary	添加或取消表情回应
+//	@Produce	json
+//	@Param		reactionReq	body		req.ReactionReq		true	"表情回应请求体"
+//	@Success	200			{object}	resp.ResponseData	"成功"
+//	@Failure	500			{object}	resp.ResponseData	"内部错误"
+//	@Router		/api/chat/reaction [post]
+func ReactionController(c *gin.Context) {
+	uid := c.GetInt64("uid")
+	reactionReq := req.ReactionReq{}
+	if err := c.ShouldBind(&reactionReq); err != nil {
+		resp.ErrorResponse(c, "参数错误")
+		c.Abort()
+		return
+	}
+	response, err := service.ReactionService(uid, reactionReq)
+	if err != nil {
+		c.Abort()
+		resp.ReturnErrorResponse(c, response)
+		return
+	}
+	resp.ReturnSuccessResponse(c, response)
+}
+
+// ForwardMessageController 转发消息
+//
+//	@Summary	逐条或合并转发消息
+//	@Produce	json
+//	@Param		forwardReq	body		req.ForwardReq		true	"转发请求体"
+//	@Success	200			{object}	resp.ResponseData	"成功"
+//	@Failure	500			{object}	resp.ResponseData	"内部错误"
+//	@Router		/api/chat/forward [post]
+func ForwardMessageController(c *gin.Context) {
+	uid := c.GetInt64("uid")
+	forwardReq := req.ForwardReq{}
+	if err := c.ShouldBind(&forwardReq); err != nil {
+		resp.ErrorResponse(c, "参数错误")
+		c.Abort()
+		return
+	}
+	response, err := service.ForwardMsgService(uid, forwardReq)
+	if err != nil {
+		c.Abort()
+		resp.ReturnErrorResponse(c, response)
+		return
+	}
+	resp.ReturnSuccessResponse(c, response)
+}
+
+// PinMessageController 置顶消息
+//
+//	@Summary	置顶消息
+//	@Produce	json
+//	@Param		msgId	body		int64				true	"消息ID"
+//	@Success	200		{object}	resp.ResponseData	"成功"
+//	@Failure	500		{object}	resp.ResponseData	"内部错误"
+//	@Router		/api/chat/pin [post]
+func PinMessageController(c *gin.Context) {
+	uid := c.GetInt64("uid")
+	pinReq := req.PinMsgReq{}
+	if err := c.ShouldBind(&pinReq); err != nil {
+		resp.ErrorResponse(c, "参数错误")
+		c.Abort()
+		return
+	}
+	response, err := service.PinMsgService(uid, pinReq)
+	if err != nil {
+		c.Abort()
+		resp.ReturnErrorResponse(c, response)
+		return
+	}
+	resp.ReturnSuccessResponse(c, response)
+}
+
+// UnpinMessageController 取消置顶消息
+//
+//	@Summary	取消置顶消息
+//	@Produce	json
+//	@Param		msgId	path		int64				true	"消息ID"
+//	@Success	200		{object}	resp.ResponseData	"成功"
+//	@Failure	500		{object}	resp.ResponseData	"内部错误"
+//	@Router		/api/chat/pin/{msgId} [delete]
+func UnpinMessageController(c *gin.Context) {
+	uid := c.GetInt64("uid")
+	unpinReq := req.UnpinMsgReq{}
+	if err := c.ShouldBindUri(&unpinReq); err != nil {
+		resp.ErrorResponse(c, "参数错误")
+		c.Abort()
+		return
+	}
+	response, err := service.UnpinMsgService(uid, unpinReq)
+	if err != nil {
+		c.Abort()
+		resp.ReturnErrorResponse(c, response)
+		return
+	}
+	resp.ReturnSuccessResponse(c, response)
+}
+
+// GetPinnedMessageController 获取置顶消息列表
+//
+//	@Summary	获取房间的置顶消息列表
+//	@Produce	json
+//	@Param		roomId	query		int64				true	"房间ID"
+//	@Success	200		{object}	resp.ResponseData	"成功"
+//	@Failure	500		{object}	resp.ResponseData	"内部错误"
+//	@Router		/api/chat/pinned [get]
+func GetPinnedMessageController(c *gin.Context) {
+	uid := c.GetInt64("uid")
+	pinnedReq := req.GetPinnedMsgReq{}
+	if err := c.ShouldBindQuery(&pinnedReq); err != nil {
+		resp.ErrorResponse(c, "参数错误")
+		c.Abort()
+		return
+	}
+	response, err := service.GetPinnedMsgService(uid, pinnedReq)
+	if err != nil {
+		c.Abort()
+		resp.ReturnErrorResponse(c, response)
+		return
+	}
+	resp.ReturnSuccessResponse(c, response)
+}
+
+// GetScheduledMsgListController 获取待发送的定时消息
+//
+//	@Summary	获取待发送的定时消息
+//	@Produce	json
+//	@Success	200	{object}	resp.ResponseData	"成功"
+//	@Failure	500	{object}	resp.ResponseData	"内部错误"
+//	@Router		/api/chat/scheduled [get]
+func GetScheduledMsgListController(c *gin.Context) {
+	uid := c.GetInt64("uid")
+	response, err := service.GetScheduledMsgListService(uid)
+	if err != nil {
+		c.Abort()
+		resp.ReturnErrorResponse(c, response)
+		return
+	}
+	resp.ReturnSuccessResponse(c, response)
+}
+
+// CancelScheduledMsgController 取消定时消息
+//
+//	@Summary	取消定时消息
+//	@Produce	json
+//	@Param		id	path		int64				true	"定时消息ID"
+//	@Success	200	{object}	resp.ResponseData	"成功"
+//	@Failure	500	{object}	resp.ResponseData	"内部错误"
+//	@Router		/api/chat/scheduled/{id} [delete]
+func CancelScheduledMsgController(c *gin.Context) {
+	uid := c.GetInt64("uid")
+	cancelReq := req.CancelScheduledMsgReq{}
+	if err := c.ShouldBindUri(&cancelReq); err != nil {
+		resp.ErrorResponse(c, "参数错误")
+		c.Abort()
+		return
+	}
+	response, err := service.CancelScheduledMsgService(uid, cancelReq)
+	if err != nil {
+		c.Abort()
+		resp.ReturnErrorResponse(c, response)
+		return
+	}
+	resp.ReturnSuccessResponse(c, response)
+}
+
+// UpdateRoomTtlController 设置房间消息自毁时长
+//
+//	@Summary	设置房间消息自毁时长
+//	@Produce	json
+//	@Param		roomId	body		int64				true	"房间ID"
+//	@Param		ttl		body		int32				true	"自毁时长(秒)，0为关闭"
+//	@Success	200		{object}	resp.ResponseData	"成功"
+//	@Failure	500		{object}	resp.ResponseData	"内部错误"
+//	@Router		/api/chat/room/ttl [put]
+func UpdateRoomTtlController(c *gin.Context) {
+	uid := c.GetInt64("uid")
+	ttlReq := req.UpdateRoomTtlReq{}
+	if err := c.ShouldBind(&ttlReq); err != nil {
+		resp.ErrorResponse(c, "参数错误")
+		c.Abort()
+		return
+	}
+	response, err := service.UpdateRoomTtlService(uid, ttlReq)
+	if err != nil {
+		c.Abort()
+		resp.ReturnErrorResponse(c, response)
+		return
+	}
+	resp.ReturnSuccessResponse(c, response)
+}
+
+// CreatePollController 发起投票
+//
+//	@Summary	发起投票
+//	@Produce	json
+//	@Param		roomId		body		int64				true	"房间ID"
+//	@Param		question	body		string				true	"投票题目"
+//	@Param		options		body		[]string			true	"投票选项"
+//	@Param		multi		body		bool				false	"是否多选"
+//	@Param		anonymous	body		bool				false	"是否匿名"
+//	@Param		deadline	body		int64				true	"截止时间 毫秒时间戳"
+//	@Success	200			{object}	resp.ResponseData	"成功"
+//	@Failure	500			{object}	resp.ResponseData	"内部错误"
+//	@Router		/api/chat/poll [post]
+func CreatePollController(c *gin.Context) {
+	uid := c.GetInt64("uid")
+	pollReq := req.CreatePollReq{}
+	if err := c.ShouldBind(&pollReq); err != nil {
+		resp.ErrorResponse(c, "参数错误")
+		c.Abort()
+		return
+	}
+	response, err := service.CreatePollService(uid, pollReq)
+	if err != nil {
+		c.Abort()
+		resp.ReturnErrorResponse(c, response)
+		return
+	}
+	resp.ReturnSuccessResponse(c, response)
+}
+
+// VotePollController 投票
+//
+//	@Summary	投票
+//	@Produce	json
+//	@Param		msgId	body		int64				true	"投票消息ID"
+//	@Param		options	body		[]int32				true	"选中的选项下标"
+//	@Success	200		{object}	resp.ResponseData	"成功"
+//	@Failure	500		{object}	resp.ResponseData	"内部错误"
+//	@Router		/api/chat/poll/vote [post]
+func VotePollController(c *gin.Context) {
+	uid := c.GetInt64("uid")
+	voteReq := req.VotePollReq{}
+	if err := c.ShouldBind(&voteReq); err != nil {
+		resp.ErrorResponse(c, "参数错误")
+		c.Abort()
+		return
+	}
+	response, err := service.VotePollService(uid, voteReq)
+	if err != nil {
+		c.Abort()
+		resp.ReturnErrorResponse(c, response)
+		return
+	}
+	resp.ReturnSuccessResponse(c, response)
+}
+
+// GetPollController 获取投票结果
+//
+//	@Summary	获取投票结果
+//	@Produce	json
+//	@Param		msgId	query		int64				true	"投票消息ID"
+//	@Success	200		{object}	resp.ResponseData	"成功"
+//	@Failure	500		{object}	resp.ResponseData	"内部错误"
+//	@Router		/api/chat/poll [get]
+func GetPollController(c *gin.Context) {
+	uid := c.GetInt64("uid")
+	pollReq := req.GetPollReq{}
+	if err := c.ShouldBindQuery(&pollReq); err != nil {
+		resp.ErrorResponse(c, "参数错误")
+		c.Abort()
+		return
+	}
+	response, err := service.GetPollService(uid, pollReq)
+	if err != nil {
+		c.Abort()
+		resp.ReturnErrorResponse(c, response)
+		return
+	}
+	resp.ReturnSuccessResponse(c, response)
+}
@@ -105,14 +105,14 @@ func GetNewContactListController(c *gin.Context) {
 //	@Failure	500	{object}	resp.ResponseData	"内部错误"
 //	@Router		/api/contact/getNewMsgList [get]
 func GetNewMsgListController(c *gin.Context) {
-
+	uid := c.GetInt64("uid")
 	getNewMsgListReq := req.GetNewMsgListReq{}
 	if err := c.ShouldBindQuery(&getNewMsgListReq); err != nil { //ShouldBind()会自动推导
 		resp.ErrorResponse(c, "参数错误")
 		c.Abort()
 		return
 	}
-	response, err := service.GetNewMsgService(getNewMsgListReq.MsgId, getNewMsgListReq.RoomId)
+	response, err := service.GetNewMsgService(uid, getNewMsgListReq.MsgId, getNewMsgListReq.RoomId)
 	if err != nil {
 		c.Abort()
 		resp.ReturnErrorResponse(c, response)
@@ -0,0 +1,138 @@
+package controller
+
+import (
+	"DiTing-Go/domain/vo/req"
+	"DiTing-Go/pkg/domain/vo/resp"
+	"DiTing-Go/service"
+	"github.com/gin-gonic/gin"
+	"net/http"
+)
+
+// AddFavoriteController 收藏消息
+//
+//	@Summary	收藏消息
+//	@Produce	json
+//	@Param		favoriteReq	body		req.AddFavoriteReq	true	"收藏请求体"
+//	@Success	200			{object}	resp.ResponseData	"成功"
+//	@Failure	500			{object}	resp.ResponseData	"内部错误"
+//	@Router		/api/chat/favorite [post]
+func AddFavoriteController(c *gin.Context) {
+	uid := c.GetInt64("uid")
+	favoriteReq := req.AddFavoriteReq{}
+	if err := c.ShouldBind(&favoriteReq); err != nil {
+		resp.ErrorResponse(c, "参数错误")
+		c.Abort()
+		return
+	}
+	response, err := service.AddFavoriteService(uid, favoriteReq)
+	if err != nil {
+		c.Abort()
+		resp.ReturnErrorResponse(c, response)
+		return
+	}
+	resp.ReturnSuccessResponse(c, response)
+}
+
+// UpdateFavoriteController 修改收藏的标签和备注
+//
+//	@Summary	修改收藏的标签和备注
+//	@Produce	json
+//	@Param		favoriteReq	body		req.UpdateFavoriteReq	true	"修改收藏请求体"
+//	@Success	200			{object}	resp.ResponseData		"成功"
+//	@Failure	500			{object}	resp.ResponseData		"内部错误"
+//	@Router		/api/chat/favorite [put]
+func UpdateFavoriteController(c *gin.Context) {
+	uid := c.GetInt64("uid")
+	favoriteReq := req.UpdateFavoriteReq{}
+	if err := c.ShouldBind(&favoriteReq); err != nil {
+		resp.ErrorResponse(c, "参数错误")
+		c.Abort()
+		return
+	}
+	response, err := service.UpdateFavoriteService(uid, favoriteReq)
+	if err != nil {
+		c.Abort()
+		resp.ReturnErrorResponse(c, response)
+		return
+	}
+	resp.ReturnSuccessResponse(c, response)
+}
+
+// DeleteFavoriteController 取消收藏
+//
+//	@Summary	取消收藏
+//	@Produce	json
+//	@Param		id	path		int64				true	"收藏ID"
+//	@Success	200	{object}	resp.ResponseData	"成功"
+//	@Failure	500	{object}	resp.ResponseData	"内部错误"
+//	@Router		/api/chat/favorite/{id} [delete]
+func DeleteFavoriteController(c *gin.Context) {
+	uid := c.GetInt64("uid")
+	favoriteReq := req.DeleteFavoriteReq{}
+	if err := c.ShouldBindUri(&favoriteReq); err != nil {
+		resp.ErrorResponse(c, "参数错误")
+		c.Abort()
+		return
+	}
+	response, err := service.DeleteFavoriteService(uid, favoriteReq)
+	if err != nil {
+		c.Abort()
+		resp.ReturnErrorResponse(c, response)
+		return
+	}
+	resp.ReturnSuccessResponse(c, response)
+}
+
+// GetFavoriteListController 获取收藏列表
+//
+//	@Summary	获取收藏列表
+//	@Produce	json
+//	@Param		cursor		query		string				false	"游标"
+//	@Param		pageSize	query		int					false	"每页条数"
+//	@Param		keyword		query		string				false	"搜索关键字"
+//	@Param		tag			query		string				false	"标签"
+//	@Success	200			{object}	resp.ResponseData	"成功"
+//	@Failure	500			{object}	resp.ResponseData	"内部错误"
+//	@Router		/api/chat/favorites [get]
+func GetFavoriteListController(c *gin.Context) {
+	uid := c.GetInt64("uid")
+	favoriteReq := req.GetFavoriteListReq{}
+	if err := c.ShouldBindQuery(&favoriteReq); err != nil {
+		resp.ErrorResponse(c, "参数错误")
+		c.Abort()
+		return
+	}
+	response, err := service.GetFavoriteListService(uid, favoriteReq)
+	if err != nil {
+		c.Abort()
+		resp.ReturnErrorResponse(c, response)
+		return
+	}
+	resp.ReturnSuccessResponse(c, response)
+}
+
+// FavoriteDownloadController 下载收藏中的文件
+//
+//	@Summary	下载收藏中的文件
+//	@Produce	json
+//	@Param		id		query		int64				true	"收藏ID"
+//	@Param		index	query		int					false	"聊天记录中的消息下标"
+//	@Success	302		{string}	string				"重定向到临时下载链接"
+//	@Failure	500		{object}	resp.ResponseData	"内部错误"
+//	@Router		/api/file/favorite [get]
+func FavoriteDownloadController(c *gin.Context) {
+	uid := c.GetInt64("uid")
+	downloadReq := req.FavoriteDownloadReq{}
+	if err := c.ShouldBindQuery(&downloadReq); err != nil {
+		resp.ErrorResponse(c, "参数错误")
+		c.Abort()
+		return
+	}
+	url, err := service.GetFavoriteDownloadUrlService(uid, downloadReq)
+	if err != nil {
+		resp.ErrorResponse(c, "文件不存在")
+		c.Abort()
+		return
+	}
+	c.Redirect(http.StatusFound, url)
+}
@@ -84,6 +84,31 @@ func AgreeFriendController(c *gin.Context) {
 	resp.ReturnSuccessResponse(c, response)
 }
 
+// RejectFriendController 拒绝好友申请
+//
+//	@Summary	拒绝好友申请
+//	@Produce	json
+//	@Param		uid	body		int					true	"申请人uid"
+//	@Success	200	{object}	resp.ResponseData	"成功"
+//	@Failure	500	{object}	resp.ResponseData	"内部错误"
+//	@Router		/api/user/reject [put]
+func RejectFriendController(c *gin.Context) {
+	uid := c.GetInt64("uid")
+	rejectFriendReq := req.RejectFriendReq{}
+	if err := c.ShouldBind(&rejectFriendReq); err != nil {
+		resp.ErrorResponse(c, "参数错误")
+		c.Abort()
+		return
+	}
+	response, err := service.RejectFriendService(uid, rejectFriendReq.Uid)
+	if err != nil {
+		c.Abort()
+		resp.ReturnErrorResponse(c, response)
+		return
+	}
+	resp.ReturnSuccessResponse(c, response)
+}
+
 // GetUserApplyController 同意好友申请
 //
 //	@Summary	同意好友申请
@@ -158,17 +183,18 @@ func UnreadApplyNumController(c *gin.Context) {
 //	@Param		uid	query		int64				true	"用户ID"
 //	@Param		page	query		int				false	"页码"
 //	@Param		size	query		int				false	"每页数量"
+//	@Param		tag	query		string				false	"好友标签"
 //	@Success	200	{object}	resp.ResponseData	"成功"
 //	@Failure	500	{object}	resp.ResponseData	"内部错误"
 //	@Router		/api/user/getFriendList [get]
 func GetFriendListController(c *gin.Context) {
 	uid := c.GetInt64("uid")
-	pageRequest := pkgReq.PageReq{}
-	if err := c.ShouldBindQuery(&pageRequest); err != nil { //ShouldBind()会自动推导
+	friendListReq := req.GetFriendListReq{}
+	if err := c.ShouldBindQuery(&friendListReq); err != nil { //ShouldBind()会自动推导
 		resp.ErrorResponse(c, "参数错误")
 		return
 	}
-	response, err := service.GetFriendListService(uid, pageRequest)
+	response, err := service.GetFriendListService(uid, friendListReq)
 	if err != nil {
 		c.Abort()
 		resp.ReturnErrorResponse(c, response)
@@ -177,12 +203,13 @@ func GetFriendListController(c *gin.Context) {
 	resp.ReturnSuccessResponse(c, response)
 }
 
-// GetUserInfoByNameController 根据好友昵称搜索好友
+// GetUserInfoByNameController 搜索用户
 //
-//	@Summary	根据好友昵称搜索好友
+//	@Summary	按uid、昵称或拼音搜索用户
 //	@Produce	json
-//	@Param		uid	query		int64				true	"用户ID"
-//	@Param		name	query		string				true	"好友昵称"
+//	@Param		name		query		string				true	"uid、昵称或拼音"
+//	@Param		cursor		query		string				false	"游标"
+//	@Param		pageSize	query		int					false	"每页条数"
 //	@Success	200	{object}	resp.ResponseData	"成功"
 //	@Failure	500	{object}	resp.ResponseData	"内部错误"
 //	@Router		/api/user/getUserInfoByName [get]
@@ -193,7 +220,145 @@ func GetUserInfoByNameController(c *gin.Context) {
 		resp.ErrorResponse(c, "参数错误")
 		return
 	}
-	response, err := service.GetUserInfoByNameService(uid, getUserInfoByNameReq.Name)
+	response, err := service.GetUserInfoByNameService(uid, getUserInfoByNameReq)
+	if err != nil {
+		c.Abort()
+		resp.ReturnErrorResponse(c, response)
+		return
+	}
+	resp.ReturnSuccessResponse(c, response)
+}
+
+// BlockUserController 拉黑用户
+//
+//	@Summary	拉黑用户
+//	@Produce	json
+//	@Param		uid	body		int					true	"被拉黑的用户ID"
+//	@Success	200	{object}	resp.ResponseData	"成功"
+//	@Failure	500	{object}	resp.ResponseData	"内部错误"
+//	@Router		/api/user/block [post]
+func BlockUserController(c *gin.Context) {
+	uid := c.GetInt64("uid")
+	blockReq := req.BlockUserReq{}
+	if err := c.ShouldBind(&blockReq); err != nil {
+		resp.ErrorResponse(c, "参数错误")
+		c.Abort()
+		return
+	}
+	response, err := service.BlockUserService(uid, blockReq)
+	if err != nil {
+		c.Abort()
+		resp.ReturnErrorResponse(c, response)
+		return
+	}
+	resp.ReturnSuccessResponse(c, response)
+}
+
+// UnblockUserController 移出黑名单
+//
+//	@Summary	移出黑名单
+//	@Produce	json
+//	@Param		uid	path		int					true	"被拉黑的用户ID"
+//	@Success	200	{object}	resp.ResponseData	"成功"
+//	@Failure	500	{object}	resp.ResponseData	"内部错误"
+//	@Router		/api/user/block/{uid} [delete]
+func UnblockUserController(c *gin.Context) {
+	uid := c.GetInt64("uid")
+	unblockReq := req.UnblockUserReq{}
+	if err := c.ShouldBindUri(&unblockReq); err != nil {
+		resp.ErrorResponse(c, "参数错误")
+		c.Abort()
+		return
+	}
+	response, err := service.UnblockUserService(uid, unblockReq)
+	if err != nil {
+		c.Abort()
+		resp.ReturnErrorResponse(c, response)
+		return
+	}
+	resp.ReturnSuccessResponse(c, response)
+}
+
+// GetBlockListController 获取黑名单列表
+//
+//	@Summary	获取黑名单列表
+//	@Produce	json
+//	@Success	200	{object}	resp.ResponseData	"成功"
+//	@Failure	500	{object}	resp.ResponseData	"内部错误"
+//	@Router		/api/user/blockList [get]
+func GetBlockListController(c *gin.Context) {
+	uid := c.GetInt64("uid")
+	response, err := service.GetBlockListService(uid)
+	if err != nil {
+		c.Abort()
+		resp.ReturnErrorResponse(c, response)
+		return
+	}
+	resp.ReturnSuccessResponse(c, response)
+}
+
+// UpdateFriendRemarkController 修改好友备注和标签
+//
+//	@Summary	修改好友备注和标签
+//	@Produce	json
+//	@Param		friendUid	body		int64				true	"好友ID"
+//	@Param		remark		body		string				false	"备注"
+//	@Param		tags		body		[]string			false	"标签"
+//	@Success	200			{object}	resp.ResponseData	"成功"
+//	@Failure	500			{object}	resp.ResponseData	"内部错误"
+//	@Router		/api/user/friend/remark [put]
+func UpdateFriendRemarkController(c *gin.Context) {
+	uid := c.GetInt64("uid")
+	remarkReq := req.UpdateFriendRemarkReq{}
+	if err := c.ShouldBind(&remarkReq); err != nil {
+		resp.ErrorResponse(c, "参数错误")
+		c.Abort()
+		return
+	}
+	response, err := service.UpdateFriendRemarkService(uid, remarkReq)
+	if err != nil {
+		c.Abort()
+		resp.ReturnErrorResponse(c, response)
+		return
+	}
+	resp.ReturnSuccessResponse(c, response)
+}
+
+// GetFriendTagsController 获取好友标签列表
+//
+//	@Summary	获取好友标签列表
+//	@Produce	json
+//	@Success	200	{object}	resp.ResponseData	"成功"
+//	@Failure	500	{object}	resp.ResponseData	"内部错误"
+//	@Router		/api/user/friend/tags [get]
+func GetFriendTagsController(c *gin.Context) {
+	uid := c.GetInt64("uid")
+	response, err := service.GetFriendTagsService(uid)
+	if err != nil {
+		c.Abort()
+		resp.ReturnErrorResponse(c, response)
+		return
+	}
+	resp.ReturnSuccessResponse(c, response)
+}
+
+// GetRecommendController 好友推荐
+//
+//	@Summary	好友推荐
+//	@Produce	json
+//	@Param		size	query		int					false	"推荐人数，默认20，最多50"
+//	@Success	200		{object}	resp.ResponseData	"成功"
+//	@Failure	500		{object}	resp.ResponseData	"内部错误"
+//	@Router		/api/user/recommendations [get]
+func GetRecommendController(c *gin.Context) {
+	uid := c.GetInt64("uid")
+	recommendReq := req.RecommendReq{}
+	if err := c.ShouldBindQuery(&recommendReq); err != nil {
+		resp.ErrorResponse(c, "参数错误")
+		c.Abort()
+		return
+	}
+	response, err := service.GetRecommendService(uid, recommendReq)
 	if err != nil {
 		c.Abort()
 		resp.ReturnErrorResponse(c, response)
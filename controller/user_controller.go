@@ -5,6 +5,7 @@ import (
 	"DiTing-Go/pkg/domain/vo/resp"
 	"DiTing-Go/service"
 	"github.com/gin-gonic/gin"
+	"net/http"
 )
 
 // RegisterController 用户注册
@@ -48,7 +49,7 @@ func LoginController(c *gin.Context) {
 		c.Abort()
 		return
 	}
-	response, err := service.LoginService(userLogin)
+	response, err := service.LoginService(userLogin, c.ClientIP())
 	if err != nil {
 		c.Abort()
 		resp.ReturnErrorResponse(c, response)
@@ -56,3 +57,242 @@ func LoginController(c *gin.Context) {
 	}
 	resp.ReturnSuccessResponse(c, response)
 }
+
+// RefreshTokenController 刷新令牌
+//
+//	@Summary	刷新令牌
+//	@Produce	json
+//	@Param		refreshToken	body		string				true	"刷新令牌"
+//	@Success	200				{object}	resp.ResponseData	"成功"
+//	@Failure	500				{object}	resp.ResponseData	"内部错误"
+//	@Router		/api/public/refresh [post]
+func RefreshTokenController(c *gin.Context) {
+	refreshReq := req.RefreshTokenReq{}
+	if err := c.ShouldBind(&refreshReq); err != nil {
+		resp.ErrorResponse(c, "参数错误")
+		c.Abort()
+		return
+	}
+	response, err := service.RefreshTokenService(refreshReq)
+	if err != nil {
+		c.Abort()
+		resp.ReturnErrorResponse(c, response)
+		return
+	}
+	resp.ReturnSuccessResponse(c, response)
+}
+
+// LogoutController 退出登录
+//
+//	@Summary	退出登录
+//	@Produce	json
+//	@Success	200	{object}	resp.ResponseData	"成功"
+//	@Failure	500	{object}	resp.ResponseData	"内部错误"
+//	@Router		/api/user/logout [post]
+func LogoutController(c *gin.Context) {
+	uid := c.GetInt64("uid")
+	sid := c.GetString("sid")
+	response, err := service.LogoutService(uid, sid)
+	if err != nil {
+		c.Abort()
+		resp.ReturnErrorResponse(c, response)
+		return
+	}
+	resp.ReturnSuccessResponse(c, response)
+}
+
+// GetDeviceListController 获取登录设备列表
+//
+//	@Summary	获取登录设备列表
+//	@Produce	json
+//	@Success	200	{object}	resp.ResponseData	"成功"
+//	@Failure	500	{object}	resp.ResponseData	"内部错误"
+//	@Router		/api/user/devices [get]
+func GetDeviceListController(c *gin.Context) {
+	uid := c.GetInt64("uid")
+	sid := c.GetString("sid")
+	response, err := service.GetDeviceListService(uid, sid)
+	if err != nil {
+		c.Abort()
+		resp.ReturnErrorResponse(c, response)
+		return
+	}
+	resp.ReturnSuccessResponse(c, response)
+}
+
+// KickDeviceController 踢下登录设备
+//
+//	@Summary	踢下登录设备
+//	@Produce	json
+//	@Param		id	path		int					true	"设备ID"
+//	@Success	200	{object}	resp.ResponseData	"成功"
+//	@Failure	500	{object}	resp.ResponseData	"内部错误"
+//	@Router		/api/user/devices/{id} [delete]
+func KickDeviceController(c *gin.Context) {
+	uid := c.GetInt64("uid")
+	kickReq := req.KickDeviceReq{}
+	if err := c.ShouldBindUri(&kickReq); err != nil {
+		resp.ErrorResponse(c, "参数错误")
+		c.Abort()
+		return
+	}
+	response, err := service.KickDeviceService(uid, kickReq)
+	if err != nil {
+		c.Abort()
+		resp.ReturnErrorResponse(c, response)
+		return
+	}
+	resp.ReturnSuccessResponse(c, response)
+}
+
+// CreateQrLoginController 创建扫码登录二维码
+//
+//	@Summary	创建扫码登录二维码
+//	@Produce	json
+//	@Param		platform	body		int					false	"登录平台 1网页 2桌面"
+//	@Param		deviceName	body		string				false	"设备名称"
+//	@Success	200			{object}	resp.ResponseData	"成功"
+//	@Failure	500			{object}	resp.ResponseData	"内部错误"
+//	@Router		/api/public/qrlogin [post]
+func CreateQrLoginController(c *gin.Context) {
+	createReq := req.CreateQrLoginReq{}
+	if err := c.ShouldBind(&createReq); err != nil {
+		resp.ErrorResponse(c, "参数错误")
+		c.Abort()
+		return
+	}
+	response, err := service.CreateQrLoginService(createReq, c.ClientIP())
+	if err != nil {
+		c.Abort()
+		resp.ReturnErrorResponse(c, response)
+		return
+	}
+	resp.ReturnSuccessResponse(c, response)
+}
+
+// PollQrLoginController 长轮询扫码登录状态
+//
+//	@Summary	长轮询扫码登录状态
+//	@Produce	json
+//	@Param		ticket	query		string				true	"二维码票据"
+//	@Success	200		{object}	resp.ResponseData	"成功"
+//	@Failure	500		{object}	resp.ResponseData	"内部错误"
+//	@Router		/api/public/qrlogin [get]
+func PollQrLoginController(c *gin.Context) {
+	pollReq := req.PollQrLoginReq{}
+	if err := c.ShouldBindQuery(&pollReq); err != nil {
+		resp.ErrorResponse(c, "参数错误")
+		c.Abort()
+		return
+	}
+	response, err := service.PollQrLoginService(c.Request.Context(), pollReq)
+	if err != nil {
+		c.Abort()
+		resp.ReturnErrorResponse(c, response)
+		return
+	}
+	resp.ReturnSuccessResponse(c, response)
+}
+
+// ConfirmQrLoginController 手机端确认扫码登录
+//
+//	@Summary	手机端确认扫码登录
+//	@Produce	json
+//	@Param		ticket	body		string				true	"二维码票据"
+//	@Success	200		{object}	resp.ResponseData	"成功"
+//	@Failure	500		{object}	resp.ResponseData	"内部错误"
+//	@Router		/api/user/qrlogin/confirm [post]
+func ConfirmQrLoginController(c *gin.Context) {
+	uid := c.GetInt64("uid")
+	confirmReq := req.ConfirmQrLoginReq{}
+	if err := c.ShouldBind(&confirmReq); err != nil {
+		resp.ErrorResponse(c, "参数错误")
+		c.Abort()
+		return
+	}
+	response, err := service.ConfirmQrLoginService(uid, confirmReq)
+	if err != nil {
+		c.Abort()
+		resp.ReturnErrorResponse(c, response)
+		return
+	}
+	resp.ReturnSuccessResponse(c, response)
+}
+
+// UpdateProfileController 修改个人资料
+//
+//	@Summary	修改个人资料
+//	@Produce	json
+//	@Param		name		body		string				false	"昵称"
+//	@Param		avatar		body		string				false	"头像地址"
+//	@Param		sex			body		int					false	"性别 1为男性，2为女性"
+//	@Param		signature	body		string				false	"个性签名"
+//	@Success	200			{object}	resp.ResponseData	"成功"
+//	@Failure	500			{object}	resp.ResponseData	"内部错误"
+//	@Router		/api/user/profile [put]
+func UpdateProfileController(c *gin.Context) {
+	uid := c.GetInt64("uid")
+	profileReq := req.UpdateProfileReq{}
+	if err := c.ShouldBind(&profileReq); err != nil {
+		resp.ErrorResponse(c, "参数错误")
+		c.Abort()
+		return
+	}
+	response, err := service.UpdateProfileService(uid, profileReq)
+	if err != nil {
+		c.Abort()
+		resp.ReturnErrorResponse(c, response)
+		return
+	}
+	resp.ReturnSuccessResponse(c, response)
+}
+
+// GetAvatarPreSignedController 签发头像上传地址
+//
+//	@Summary	签发头像上传地址
+//	@Produce	json
+//	@Param		contentType	query		string				true	"文件类型"
+//	@Param		size		query		int64				true	"文件大小"
+//	@Success	200			{object}	resp.ResponseData	"成功"
+//	@Failure	500			{object}	resp.ResponseData	"内部错误"
+//	@Router		/api/user/avatar/preSigned [get]
+func GetAvatarPreSignedController(c *gin.Context) {
+	uid := c.GetInt64("uid")
+	preSignedReq := req.AvatarPreSignedReq{}
+	if err := c.ShouldBindQuery(&preSignedReq); err != nil {
+		resp.ErrorResponse(c, "参数错误")
+		c.Abort()
+		return
+	}
+	response, err := service.GetAvatarPreSignedService(uid, preSignedReq)
+	if err != nil {
+		c.Abort()
+		resp.ReturnErrorResponse(c, response)
+		return
+	}
+	resp.ReturnSuccessResponse(c, response)
+}
+
+// AvatarController 访问头像
+//
+//	@Summary	访问头像
+//	@Produce	json
+//	@Param		key	query		string				true	"头像文件"
+//	@Success	302	{string}	string				"重定向到临时下载链接"
+//	@Failure	500	{object}	resp.ResponseData	"内部错误"
+//	@Router		/api/public/avatar [get]
+func AvatarController(c *gin.Context) {
+	avatarReq := req.AvatarReq{}
+	if err := c.ShouldBindQuery(&avatarReq); err != nil {
+		resp.ErrorResponse(c, "参数错误")
+		c.Abort()
+		return
+	}
+	avatarUrl, err := service.GetAvatarUrlService(avatarReq)
+	if err != nil {
+		resp.ErrorResponse(c, "头像不存在")
+		c.Abort()
+		return
+	}
+	c.Redirect(http.StatusFound, avatarUrl)
+}
@@ -21,6 +21,10 @@ type Message struct {
 	GapCount     int32     `gorm:"column:gap_count;comment:与回复的消息间隔多少条" json:"gap_count"`                                    // 与回复的消息间隔多少条
 	Type         int32     `gorm:"column:type;default:1;comment:消息类型 1正常文本 2.撤回消息" json:"type"`                              // 消息类型 1正常文本 2.撤回消息
 	Extra        string    `gorm:"column:extra;comment:扩展信息" json:"extra"`                                                   // 扩展信息
+	ClientMsgID  string    `gorm:"column:client_msg_id;default:NULL;comment:客户端消息id，同一发送者唯一" json:"client_msg_id"`           // 客户端消息id，同一发送者唯一
+	Forwarded    int32     `gorm:"column:forwarded;default:1;comment:是否转发的消息 1否 2是" json:"forwarded"`                        // 是否转发的消息 1否 2是
+	ExpireAt     time.Time `gorm:"column:expire_at;default:NULL;comment:自毁时间，为空不自毁" json:"expire_at"`                        // 自毁时间，为空不自毁
+	EditTime     time.Time `gorm:"column:edit_time;default:NULL;comment:最后编辑时间，未编辑为空" json:"edit_time"`                      // 最后编辑时间，未编辑为空
 	CreateTime   time.Time `gorm:"column:create_time;not null;default:CURRENT_TIMESTAMP(3);comment:创建时间" json:"create_time"` // 创建时间
 	UpdateTime   time.Time `gorm:"column:update_time;not null;default:CURRENT_TIMESTAMP(3);comment:修改时间" json:"update_time"` // 修改时间
 }
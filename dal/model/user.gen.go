@@ -13,10 +13,13 @@ const TableNameUser = "user"
 // User 用户表
 type User struct {
 	ID           int64     `gorm:"column:id;primaryKey;autoIncrement:true;comment:用户id" json:"id"`                                  // 用户id
-	Password     string    `gorm:"column:password;comment:用户密码" json:"password"`                                                    // 用户密码
+	Password     string    `gorm:"column:password;comment:用户密码" json:"-"`                                                           // 用户密码
 	Name         string    `gorm:"column:name;comment:用户昵称" json:"name"`                                                            // 用户昵称
+	NamePinyin   string    `gorm:"column:name_pinyin;comment:昵称拼音，全拼和首字母，用于搜索" json:"name_pinyin"`                                  // 昵称拼音，全拼和首字母，用于搜索
 	Avatar       string    `gorm:"column:avatar;comment:用户头像" json:"avatar"`                                                        // 用户头像
 	Sex          int32     `gorm:"column:sex;comment:性别 1为男性，2为女性" json:"sex"`                                                      // 性别 1为男性，2为女性
+	Signature    string    `gorm:"column:signature;comment:个性签名" json:"signature"`                                                  // 个性签名
+	Discoverable int32     `gorm:"column:discoverable;default:1;comment:是否允许通过昵称搜索 1允许 2不允许" json:"discoverable"`                   // 是否允许通过昵称搜索 1允许 2不允许
 	OpenID       string    `gorm:"column:open_id;comment:微信openid用户标识" json:"open_id"`                                              // 微信openid用户标识
 	ActiveStatus int32     `gorm:"column:active_status;default:2;comment:在线状态 1在线 2离线" json:"active_status"`                        // 在线状态 1在线 2离线
 	LastOptTime  time.Time `gorm:"column:last_opt_time;not null;default:CURRENT_TIMESTAMP(3);comment:最后上下线时间" json:"last_opt_time"` // 最后上下线时间
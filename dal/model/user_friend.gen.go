@@ -15,6 +15,8 @@ type UserFriend struct {
 	ID           int64     `gorm:"column:id;primaryKey;autoIncrement:true;comment:id" json:"id"`                             // id
 	UID          int64     `gorm:"column:uid;not null;comment:uid" json:"uid"`                                               // uid
 	FriendUID    int64     `gorm:"column:friend_uid;not null;comment:好友uid" json:"friend_uid"`                               // 好友uid
+	Remark       string    `gorm:"column:remark;not null;comment:好友备注" json:"remark"`                                        // 好友备注
+	Tags         string    `gorm:"column:tags;not null;comment:好友标签，逗号分隔" json:"tags"`                                       // 好友标签，逗号分隔
 	DeleteStatus int32     `gorm:"column:delete_status;not null;comment:逻辑删除(0-正常,1-删除)" json:"delete_status"`               // 逻辑删除(0-正常,1-删除)
 	CreateTime   time.Time `gorm:"column:create_time;not null;default:CURRENT_TIMESTAMP(3);comment:创建时间" json:"create_time"` // 创建时间
 	UpdateTime   time.Time `gorm:"column:update_time;not null;default:CURRENT_TIMESTAMP(3);comment:修改时间" json:"update_time"` // 修改时间
@@ -16,74 +16,124 @@ import (
 )
 
 var (
-	Q           = new(Query)
-	Contact     *contact
-	GroupMember *groupMember
-	Message     *message
-	Room        *room
-	RoomFriend  *roomFriend
-	RoomGroup   *roomGroup
-	User        *user
-	UserApply   *userApply
-	UserFriend  *userFriend
+	Q                  = new(Query)
+	Contact            *contact
+	EventOutbox        *eventOutbox
+	GroupMember        *groupMember
+	Message            *message
+	MessageEditHistory *messageEditHistory
+	MessagePin         *messagePin
+	MessageReaction    *messageReaction
+	ObjectRef          *objectRef
+	PollVote           *pollVote
+	Room               *room
+	RoomFriend         *roomFriend
+	RoomGroup          *roomGroup
+	ScheduledMessage   *scheduledMessage
+	User               *user
+	UserApply          *userApply
+	UserBlock          *userBlock
+	UserDevice         *userDevice
+	UserFavorite       *userFavorite
+	UserFriend         *userFriend
 )
 
 func SetDefault(db *gorm.DB, opts ...gen.DOOption) {
 	*Q = *Use(db, opts...)
 	Contact = &Q.Contact
+	EventOutbox = &Q.EventOutbox
 	GroupMember = &Q.GroupMember
 	Message = &Q.Message
+	MessageEditHistory = &Q.MessageEditHistory
+	MessagePin = &Q.MessagePin
+	MessageReaction = &Q.MessageReaction
+	ObjectRef = &Q.ObjectRef
+	PollVote = &Q.PollVote
 	Room = &Q.Room
 	RoomFriend = &Q.RoomFriend
 	RoomGroup = &Q.RoomGroup
+	ScheduledMessage = &Q.ScheduledMessage
 	User = &Q.User
 	UserApply = &Q.UserApply
+	UserBlock = &Q.UserBlock
+	UserDevice = &Q.UserDevice
+	UserFavorite = &Q.UserFavorite
 	UserFriend = &Q.UserFriend
 }
 
 func Use(db *gorm.DB, opts ...gen.DOOption) *Query {
 	return &Query{
-		db:          db,
-		Contact:     newContact(db, opts...),
-		GroupMember: newGroupMember(db, opts...),
-		Message:     newMessage(db, opts...),
-		Room:        newRoom(db, opts...),
-		RoomFriend:  newRoomFriend(db, opts...),
-		RoomGroup:   newRoomGroup(db, opts...),
-		User:        newUser(db, opts...),
-		UserApply:   newUserApply(db, opts...),
-		UserFriend:  newUserFriend(db, opts...),
+		db:                 db,
+		Contact:            newContact(db, opts...),
+		EventOutbox:        newEventOutbox(db, opts...),
+		GroupMember:        newGroupMember(db, opts...),
+		Message:            newMessage(db, opts...),
+		MessageEditHistory: newMessageEditHistory(db, opts...),
+		MessagePin:         newMessagePin(db, opts...),
+		MessageReaction:    newMessageReaction(db, opts...),
+		ObjectRef:          newObjectRef(db, opts...),
+		PollVote:           newPollVote(db, opts...),
+		Room:               newRoom(db, opts...),
+		RoomFriend:         newRoomFriend(db, opts...),
+		RoomGroup:          newRoomGroup(db, opts...),
+		ScheduledMessage:   newScheduledMessage(db, opts...),
+		User:               newUser(db, opts...),
+		UserApply:          newUserApply(db, opts...),
+		UserBlock:          newUserBlock(db, opts...),
+		UserDevice:         newUserDevice(db, opts...),
+		UserFavorite:       newUserFavorite(db, opts...),
+		UserFriend:         newUserFriend(db, opts...),
 	}
 }
 
 type Query struct {
 	db *gorm.DB
 
-	Contact     contact
-	GroupMember groupMember
-	Message     message
-	Room        room
-	RoomFriend  roomFriend
-	RoomGroup   roomGroup
-	User        user
-	UserApply   userApply
-	UserFriend  userFriend
+	Contact            contact
+	EventOutbox        eventOutbox
+	GroupMember        groupMember
+	Message            message
+	MessageEditHistory messageEditHistory
+	MessagePin         messagePin
+	MessageReaction    messageReaction
+	ObjectRef          objectRef
+	PollVote           pollVote
+	Room               room
+	RoomFriend         roomFriend
+	RoomGroup          roomGroup
+	ScheduledMessage   scheduledMessage
+	User               user
+	UserApply          userApply
+	UserBlock          userBlock
+	UserDevice         userDevice
+	UserFavorite       userFavorite
+	UserFriend         userFriend
 }
 
 func (q *Query) Available() bool { return q.db != nil }
 
 func (q *Query) clone(db *gorm.DB) *Query {
 	return &Query{
-		db:          db,
-		Contact:     q.Contact.clone(db),
-		GroupMember: q.GroupMember.clone(db),
-		Message:     q.Message.clone(db),
-		Room:        q.Room.clone(db),
-		RoomFriend:  q.RoomFriend.clone(db),
-		RoomGroup:   q.RoomGroup.clone(db),
-		User:        q.User.clone(db),
-		UserApply:   q.UserApply.clone(db),
-		UserFriend:  q.UserFriend.clone(db),
+		db:                 db,
+		Contact:            q.Contact.clone(db),
+		EventOutbox:        q.EventOutbox.clone(db),
+		GroupMember:        q.GroupMember.clone(db),
+		Message:            q.Message.clone(db),
+		MessageEditHistory: q.MessageEditHistory.clone(db),
+		MessagePin:         q.MessagePin.clone(db),
+		MessageReaction:    q.MessageReaction.clone(db),
+		ObjectRef:          q.ObjectRef.clone(db),
+		PollVote:           q.PollVote.clone(db),
+		Room:               q.Room.clone(db),
+		RoomFriend:         q.RoomFriend.clone(db),
+		RoomGroup:          q.RoomGroup.clone(db),
+		ScheduledMessage:   q.ScheduledMessage.clone(db),
+		User:               q.User.clone(db),
+		UserApply:          q.UserApply.clone(db),
+		UserBlock:          q.UserBlock.clone(db),
+		UserDevice:         q.UserDevice.clone(db),
+		UserFavorite:       q.UserFavorite.clone(db),
+		UserFriend:         q.UserFriend.clone(db),
 	}
 }
 
@@ -97,42 +147,72 @@ func (q *Query) WriteDB() *Query {
 
 func (q *Query) ReplaceDB(db *gorm.DB) *Query {
 	return &Query{
-		db:          db,
-		Contact:     q.Contact.replaceDB(db),
-		GroupMember: q.GroupMember.replaceDB(db),
-		Message:     q.Message.replaceDB(db),
-		Room:        q.Room.replaceDB(db),
-		RoomFriend:  q.RoomFriend.replaceDB(db),
-		RoomGroup:   q.RoomGroup.replaceDB(db),
-		User:        q.User.replaceDB(db),
-		UserApply:   q.UserApply.replaceDB(db),
-		UserFriend:  q.UserFriend.replaceDB(db),
+		db:                 db,
+		Contact:            q.Contact.replaceDB(db),
+		EventOutbox:        q.EventOutbox.replaceDB(db),
+		GroupMember:        q.GroupMember.replaceDB(db),
+		Message:            q.Message.replaceDB(db),
+		MessageEditHistory: q.MessageEditHistory.replaceDB(db),
+		MessagePin:         q.MessagePin.replaceDB(db),
+		MessageReaction:    q.MessageReaction.replaceDB(db),
+		ObjectRef:          q.ObjectRef.replaceDB(db),
+		PollVote:           q.PollVote.replaceDB(db),
+		Room:               q.Room.replaceDB(db),
+		RoomFriend:         q.RoomFriend.replaceDB(db),
+		RoomGroup:          q.RoomGroup.replaceDB(db),
+		ScheduledMessage:   q.ScheduledMessage.replaceDB(db),
+		User:               q.User.replaceDB(db),
+		UserApply:          q.UserApply.replaceDB(db),
+		UserBlock:          q.UserBlock.replaceDB(db),
+		UserDevice:         q.UserDevice.replaceDB(db),
+		UserFavorite:       q.UserFavorite.replaceDB(db),
+		UserFriend:         q.UserFriend.replaceDB(db),
 	}
 }
 
 type queryCtx struct {
-	Contact     IContactDo
-	GroupMember IGroupMemberDo
-	Message     IMessageDo
-	Room        IRoomDo
-	RoomFriend  IRoomFriendDo
-	RoomGroup   IRoomGroupDo
-	User        IUserDo
-	UserApply   IUserApplyDo
-	UserFriend  IUserFriendDo
+	Contact            IContactDo
+	EventOutbox        IEventOutboxDo
+	GroupMember        IGroupMemberDo
+	Message            IMessageDo
+	MessageEditHistory IMessageEditHistoryDo
+	MessagePin         IMessagePinDo
+	MessageReaction    IMessageReactionDo
+	ObjectRef          IObjectRefDo
+	PollVote           IPollVoteDo
+	Room               IRoomDo
+	RoomFriend         IRoomFriendDo
+	RoomGroup          IRoomGroupDo
+	ScheduledMessage   IScheduledMessageDo
+	User               IUserDo
+	UserApply          IUserApplyDo
+	UserBlock          IUserBlockDo
+	UserDevice         IUserDeviceDo
+	UserFavorite       IUserFavoriteDo
+	UserFriend         IUserFriendDo
 }
 
 func (q *Query) WithContext(ctx context.Context) *queryCtx {
 	return &queryCtx{
-		Contact:     q.Contact.WithContext(ctx),
-		GroupMember: q.GroupMember.WithContext(ctx),
-		Message:     q.Message.WithContext(ctx),
-		Room:        q.Room.WithContext(ctx),
-		RoomFriend:  q.RoomFriend.WithContext(ctx),
-		RoomGroup:   q.RoomGroup.WithContext(ctx),
-		User:        q.User.WithContext(ctx),
-		UserApply:   q.UserApply.WithContext(ctx),
-		UserFriend:  q.UserFriend.WithContext(ctx),
+		Contact:            q.Contact.WithContext(ctx),
+		EventOutbox:        q.EventOutbox.WithContext(ctx),
+		GroupMember:        q.GroupMember.WithContext(ctx),
+		Message:            q.Message.WithContext(ctx),
+		MessageEditHistory: q.MessageEditHistory.WithContext(ctx),
+		MessagePin:         q.MessagePin.WithContext(ctx),
+		MessageReaction:    q.MessageReaction.WithContext(ctx),
+		ObjectRef:          q.ObjectRef.WithContext(ctx),
+		PollVote:           q.PollVote.WithContext(ctx),
+		Room:               q.Room.WithContext(ctx),
+		RoomFriend:         q.RoomFriend.WithContext(ctx),
+		RoomGroup:          q.RoomGroup.WithContext(ctx),
+		ScheduledMessage:   q.ScheduledMessage.WithContext(ctx),
+		User:               q.User.WithContext(ctx),
+		UserApply:          q.UserApply.WithContext(ctx),
+		UserBlock:          q.UserBlock.WithContext(ctx),
+		UserDevice:         q.UserDevice.WithContext(ctx),
+		UserFavorite:       q.UserFavorite.WithContext(ctx),
+		UserFriend:         q.UserFriend.WithContext(ctx),
 	}
 }
 
@@ -36,6 +36,10 @@ func newMessage(db *gorm.DB, opts ...gen.DOOption) message {
 	_message.GapCount = field.NewInt32(tableName, "gap_count")
 	_message.Type = field.NewInt32(tableName, "type")
 	_message.Extra = field.NewString(tableName, "extra")
+	_message.ClientMsgID = field.NewString(tableName, "client_msg_id")
+	_message.Forwarded = field.NewInt32(tableName, "forwarded")
+	_message.ExpireAt = field.NewTime(tableName, "expire_at")
+	_message.EditTime = field.NewTime(tableName, "edit_time")
 	_message.CreateTime = field.NewTime(tableName, "create_time")
 	_message.UpdateTime = field.NewTime(tableName, "update_time")
 
@@ -58,6 +62,10 @@ type message struct {
 	GapCount     field.Int32  // 与回复的消息间隔多少条
 	Type         field.Int32  // 消息类型 1正常文本 2.撤回消息
 	Extra        field.String // 扩展信息
+	ClientMsgID  field.String // 客户端消息id，同一发送者唯一
+	Forwarded    field.Int32  // 是否转发的消息 1否 2是
+	ExpireAt     field.Time   // 自毁时间，为空不自毁
+	EditTime     field.Time   // 最后编辑时间，未编辑为空
 	CreateTime   field.Time   // 创建时间
 	UpdateTime   field.Time   // 修改时间
 
@@ -85,6 +93,10 @@ func (m *message) updateTableName(table string) *message {
 	m.GapCount = field.NewInt32(table, "gap_count")
 	m.Type = field.NewInt32(table, "type")
 	m.Extra = field.NewString(table, "extra")
+	m.ClientMsgID = field.NewString(table, "client_msg_id")
+	m.Forwarded = field.NewInt32(table, "forwarded")
+	m.ExpireAt = field.NewTime(table, "expire_at")
+	m.EditTime = field.NewTime(table, "edit_time")
 	m.CreateTime = field.NewTime(table, "create_time")
 	m.UpdateTime = field.NewTime(table, "update_time")
 
@@ -111,7 +123,7 @@ func (m *message) GetFieldByName(fieldName string) (field.OrderExpr, bool) {
 }
 
 func (m *message) fillFieldMap() {
-	m.fieldMap = make(map[string]field.Expr, 11)
+	m.fieldMap = make(map[string]field.Expr, 15)
 	m.fieldMap["id"] = m.ID
 	m.fieldMap["room_id"] = m.RoomID
 	m.fieldMap["from_uid"] = m.FromUID
@@ -121,6 +133,10 @@ func (m *message) fillFieldMap() {
 	m.fieldMap["gap_count"] = m.GapCount
 	m.fieldMap["type"] = m.Type
 	m.fieldMap["extra"] = m.Extra
+	m.fieldMap["client_msg_id"] = m.ClientMsgID
+	m.fieldMap["forwarded"] = m.Forwarded
+	m.fieldMap["expire_at"] = m.ExpireAt
+	m.fieldMap["edit_time"] = m.EditTime
 	m.fieldMap["create_time"] = m.CreateTime
 	m.fieldMap["update_time"] = m.UpdateTime
 }
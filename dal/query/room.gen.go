@@ -32,6 +32,7 @@ func newRoom(db *gorm.DB, opts ...gen.DOOption) room {
 	_room.HotFlag = field.NewInt32(tableName, "hot_flag")
 	_room.ActiveTime = field.NewTime(tableName, "active_time")
 	_room.LastMsgID = field.NewInt64(tableName, "last_msg_id")
+	_room.MsgTTL = field.NewInt32(tableName, "msg_ttl")
 	_room.ExtJSON = field.NewString(tableName, "ext_json")
 	_room.CreateTime = field.NewTime(tableName, "create_time")
 	_room.UpdateTime = field.NewTime(tableName, "update_time")
@@ -52,6 +53,7 @@ type room struct {
 	HotFlag      field.Int32  // 是否全员展示 0否 1是
 	ActiveTime   field.Time   // 群最后消息的更新时间（热点群不需要写扩散，只更新这里）
 	LastMsgID    field.Int64  // 会话中的最后一条消息id
+	MsgTTL       field.Int32  // 消息自毁时长(秒)，0为关闭
 	ExtJSON      field.String // 额外信息（根据不同类型房间有不同存储的东西）
 	CreateTime   field.Time   // 创建时间
 	UpdateTime   field.Time   // 修改时间
@@ -77,6 +79,7 @@ func (r *room) updateTableName(table string) *room {
 	r.HotFlag = field.NewInt32(table, "hot_flag")
 	r.ActiveTime = field.NewTime(table, "active_time")
 	r.LastMsgID = field.NewInt64(table, "last_msg_id")
+	r.MsgTTL = field.NewInt32(table, "msg_ttl")
 	r.ExtJSON = field.NewString(table, "ext_json")
 	r.CreateTime = field.NewTime(table, "create_time")
 	r.UpdateTime = field.NewTime(table, "update_time")
@@ -105,12 +108,13 @@ func (r *room) GetFieldByName(fieldName string) (field.OrderExpr, bool) {
 }
 
 func (r *room) fillFieldMap() {
-	r.fieldMap = make(map[string]field.Expr, 9)
+	r.fieldMap = make(map[string]field.Expr, 10)
 	r.fieldMap["id"] = r.ID
 	r.fieldMap["type"] = r.Type
 	r.fieldMap["hot_flag"] = r.HotFlag
 	r.fieldMap["active_time"] = r.ActiveTime
 	r.fieldMap["last_msg_id"] = r.LastMsgID
+	r.fieldMap["msg_ttl"] = r.MsgTTL
 	r.fieldMap["ext_json"] = r.ExtJSON
 	r.fieldMap["create_time"] = r.CreateTime
 	r.fieldMap["update_time"] = r.UpdateTime
@@ -30,8 +30,11 @@ func newUser(db *gorm.DB, opts ...gen.DOOption) user {
 	_user.ID = field.NewInt64(tableName, "id")
 	_user.Password = field.NewString(tableName, "password")
 	_user.Name = field.NewString(tableName, "name")
+	_user.NamePinyin = field.NewString(tableName, "name_pinyin")
 	_user.Avatar = field.NewString(tableName, "avatar")
 	_user.Sex = field.NewInt32(tableName, "sex")
+	_user.Signature = field.NewString(tableName, "signature")
+	_user.Discoverable = field.NewInt32(tableName, "discoverable")
 	_user.OpenID = field.NewString(tableName, "open_id")
 	_user.ActiveStatus = field.NewInt32(tableName, "active_status")
 	_user.LastOptTime = field.NewTime(tableName, "last_opt_time")
@@ -54,8 +57,11 @@ type user struct {
 	ID           field.Int64  // 用户id
 	Password     field.String // 用户密码
 	Name         field.String // 用户昵称
+	NamePinyin   field.String // 昵称拼音，全拼和首字母，用于搜索
 	Avatar       field.String // 用户头像
 	Sex          field.Int32  // 性别 1为男性，2为女性
+	Signature    field.String // 个性签名
+	Discoverable field.Int32  // 是否允许通过昵称搜索 1允许 2不允许
 	OpenID       field.String // 微信openid用户标识
 	ActiveStatus field.Int32  // 在线状态 1在线 2离线
 	LastOptTime  field.Time   // 最后上下线时间
@@ -83,8 +89,11 @@ func (u *user) updateTableName(table string) *user {
 	u.ID = field.NewInt64(table, "id")
 	u.Password = field.NewString(table, "password")
 	u.Name = field.NewString(table, "name")
+	u.NamePinyin = field.NewString(table, "name_pinyin")
 	u.Avatar = field.NewString(table, "avatar")
 	u.Sex = field.NewInt32(table, "sex")
+	u.Signature = field.NewString(table, "signature")
+	u.Discoverable = field.NewInt32(table, "discoverable")
 	u.OpenID = field.NewString(table, "open_id")
 	u.ActiveStatus = field.NewInt32(table, "active_status")
 	u.LastOptTime = field.NewTime(table, "last_opt_time")
@@ -117,12 +126,15 @@ func (u *user) GetFieldByName(fieldName string) (field.OrderExpr, bool) {
 }
 
 func (u *user) fillFieldMap() {
-	u.fieldMap = make(map[string]field.Expr, 13)
+	u.fieldMap = make(map[string]field.Expr, 16)
 	u.fieldMap["id"] = u.ID
 	u.fieldMap["password"] = u.Password
 	u.fieldMap["name"] = u.Name
+	u.fieldMap["name_pinyin"] = u.NamePinyin
 	u.fieldMap["avatar"] = u.Avatar
 	u.fieldMap["sex"] = u.Sex
+	u.fieldMap["signature"] = u.Signature
+	u.fieldMap["discoverable"] = u.Discoverable
 	u.fieldMap["open_id"] = u.OpenID
 	u.fieldMap["active_status"] = u.ActiveStatus
 	u.fieldMap["last_opt_time"] = u.LastOptTime
@@ -52,7 +52,7 @@ type userApply struct {
 	Type       field.Int32  // 申请类型 1加好友
 	TargetID   field.Int64  // 接收人uid
 	Msg        field.String // 申请信息
-	Status     field.Int32  // 申请状态 1待审批 2同意
+	Status     field.Int32  // 申请状态 1待审批 2同意 3拒绝 4过期
 	ReadStatus field.Int32  // 阅读状态 1未读 2已读
 	CreateTime field.Time   // 创建时间
 	UpdateTime field.Time   // 修改时间
@@ -30,6 +30,8 @@ func newUserFriend(db *gorm.DB, opts ...gen.DOOption) userFriend {
 	_userFriend.ID = field.NewInt64(tableName, "id")
 	_userFriend.UID = field.NewInt64(tableName, "uid")
 	_userFriend.FriendUID = field.NewInt64(tableName, "friend_uid")
+	_userFriend.Remark = field.NewString(tableName, "remark")
+	_userFriend.Tags = field.NewString(tableName, "tags")
 	_userFriend.DeleteStatus = field.NewInt32(tableName, "delete_status")
 	_userFriend.CreateTime = field.NewTime(tableName, "create_time")
 	_userFriend.UpdateTime = field.NewTime(tableName, "update_time")
@@ -44,12 +46,14 @@ type userFriend struct {
 	userFriendDo userFriendDo
 
 	ALL          field.Asterisk
-	ID           field.Int64 // id
-	UID          field.Int64 // uid
-	FriendUID    field.Int64 // 好友uid
-	DeleteStatus field.Int32 // 逻辑删除(0-正常,1-删除)
-	CreateTime   field.Time  // 创建时间
-	UpdateTime   field.Time  // 修改时间
+	ID           field.Int64  // id
+	UID          field.Int64  // uid
+	FriendUID    field.Int64  // 好友uid
+	Remark       field.String // 好友备注
+	Tags         field.String // 好友标签，逗号分隔
+	DeleteStatus field.Int32  // 逻辑删除(0-正常,1-删除)
+	CreateTime   field.Time   // 创建时间
+	UpdateTime   field.Time   // 修改时间
 
 	fieldMap map[string]field.Expr
 }
@@ -69,6 +73,8 @@ func (u *userFriend) updateTableName(table string) *userFriend {
 	u.ID = field.NewInt64(table, "id")
 	u.UID = field.NewInt64(table, "uid")
 	u.FriendUID = field.NewInt64(table, "friend_uid")
+	u.Remark = field.NewString(table, "remark")
+	u.Tags = field.NewString(table, "tags")
 	u.DeleteStatus = field.NewInt32(table, "delete_status")
 	u.CreateTime = field.NewTime(table, "create_time")
 	u.UpdateTime = field.NewTime(table, "update_time")
@@ -98,10 +104,12 @@ func (u *userFriend) GetFieldByName(fieldName string) (field.OrderExpr, bool) {
 }
 
 func (u *userFriend) fillFieldMap() {
-	u.fieldMap = make(map[string]field.Expr, 6)
+	u.fieldMap = make(map[string]field.Expr, 8)
 	u.fieldMap["id"] = u.ID
 	u.fieldMap["uid"] = u.UID
 	u.fieldMap["friend_uid"] = u.FriendUID
+	u.fieldMap["remark"] = u.Remark
+	u.fieldMap["tags"] = u.Tags
 	u.fieldMap["delete_status"] = u.DeleteStatus
 	u.fieldMap["create_time"] = u.CreateTime
 	u.fieldMap["update_time"] = u.UpdateTime
@@ -17,4 +17,6 @@ type ContactDto struct {
 	UnreadCount int32 `json:"unreadCount"`
 	// 会话类型
 	Type int `json:"type"`
+	// 消息自毁时长(秒)，0为关闭
+	MsgTtl int32 `json:"msgTtl"`
 }
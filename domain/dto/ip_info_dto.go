@@ -0,0 +1,9 @@
+package dto
+
+// IpInfo 用户ip信息，对应 user.ip_info
+type IpInfo struct {
+	// 注册后首次登录ip
+	CreateIp string `json:"createIp"`
+	// 最近一次登录ip
+	UpdateIp string `json:"updateIp"`
+}
@@ -1,5 +1,7 @@
 package dto
 
+import "encoding/json"
+
 type MessageBaseDto struct {
 	// 下载地址
 	Url string `json:"url"`
@@ -9,6 +11,34 @@ type MessageBaseDto struct {
 	Name string `json:"name"`
 }
 
+// ChatHistoryDto 合并转发的聊天记录
+type ChatHistoryDto struct {
+	// 来源房间ID
+	RoomId int64 `json:"room_id"`
+	// 消息快照
+	Messages []ChatHistoryItemDto `json:"messages"`
+}
+
+type ChatHistoryItemDto struct {
+	MsgId   int64  `json:"msg_id"`
+	FromUid int64  `json:"from_uid"`
+	Name    string `json:"name"`
+	Avatar  string `json:"avatar"`
+	Type    int32  `json:"type"`
+	Content string `json:"content"`
+	// 原消息的扩展信息，嵌套的聊天记录只保存 ChatHistorySummaryDto
+	Extra    json.RawMessage `json:"extra"`
+	SendTime int64           `json:"send_time"`
+}
+
+// ChatHistorySummaryDto 嵌套聊天记录的摘要，不再展开其中的消息
+type ChatHistorySummaryDto struct {
+	// 来源房间ID
+	RoomId int64 `json:"room_id"`
+	// 消息条数
+	Count int `json:"count"`
+}
+
 type ImgMessageDto struct {
 	MessageBaseDto MessageBaseDto `json:"message_base_dto"`
 	// 图片高度
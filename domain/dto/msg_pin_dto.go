@@ -0,0 +1,8 @@
+package dto
+
+type MsgPinDto struct {
+	RoomId int64 `json:"roomId"`
+	MsgId  int64 `json:"msgId"`
+	Uid    int64 `json:"uid"`
+	Action int32 `json:"action"`
+}
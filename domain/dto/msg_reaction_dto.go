@@ -0,0 +1,9 @@
+package dto
+
+type MsgReactionDto struct {
+	MsgId  int64  `json:"msgId"`
+	RoomId int64  `json:"roomId"`
+	Uid    int64  `json:"uid"`
+	Emoji  string `json:"emoji"`
+	Action int32  `json:"action"`
+}
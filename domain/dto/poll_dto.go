@@ -0,0 +1,19 @@
+package dto
+
+// PollDto 投票消息的 extra
+type PollDto struct {
+	Question string   `json:"question"`
+	Options  []string `json:"options"`
+	// 是否多选
+	Multi bool `json:"multi"`
+	// 是否匿名，匿名投票不展示投票人
+	Anonymous bool `json:"anonymous"`
+	// 截止时间 毫秒时间戳
+	Deadline int64 `json:"deadline"`
+}
+
+type PollVoteDto struct {
+	MsgId  int64 `json:"msgId"`
+	RoomId int64 `json:"roomId"`
+	Uid    int64 `json:"uid"`
+}
@@ -0,0 +1,17 @@
+package dto
+
+// QrLoginTicketDto 扫码登录票据
+type QrLoginTicketDto struct {
+	// 票据状态
+	Status int `json:"status"`
+	// 网页端登录平台
+	Platform int32 `json:"platform"`
+	// 网页端设备名称
+	DeviceName string `json:"deviceName"`
+	// 网页端ip
+	Ip string `json:"ip"`
+	// 确认登录的用户ID
+	Uid int64 `json:"uid"`
+	// 手机确认后为网页端创建的会话ID，令牌在网页端领取时签发
+	Sid string `json:"sid"`
+}
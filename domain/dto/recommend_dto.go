@@ -0,0 +1,12 @@
+package dto
+
+// RecommendDto 好友推荐候选人
+type RecommendDto struct {
+	Uid int64 `json:"uid"`
+	// 共同好友数
+	MutualFriends int64 `json:"mutualFriends"`
+	// 共同群聊数
+	SharedGroups int64 `json:"sharedGroups"`
+	// 排序分数
+	Score int64 `json:"score"`
+}
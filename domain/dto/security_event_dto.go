@@ -0,0 +1,19 @@
+package dto
+
+import "time"
+
+// SecurityEventDto 安全事件，供管理员分析攻击行为
+type SecurityEventDto struct {
+	// 事件类型
+	Type string `json:"type"`
+	// 登录用户名
+	Username string `json:"username"`
+	// 来源ip
+	Ip string `json:"ip"`
+	// 统计窗口内的失败次数
+	Failures int64 `json:"failures"`
+	// 锁定时长，单位秒
+	LockSeconds int64 `json:"lockSeconds"`
+	// 发生时间
+	Time time.Time `json:"time"`
+}
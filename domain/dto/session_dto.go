@@ -0,0 +1,21 @@
+package dto
+
+import "time"
+
+// SessionDto 登录会话
+type SessionDto struct {
+	// 用户ID
+	Uid int64 `json:"uid"`
+	// 会话ID
+	Sid string `json:"sid"`
+	// 当前有效的刷新令牌摘要
+	RefreshHash string `json:"refreshHash"`
+	// 登录时间
+	CreateTime time.Time `json:"createTime"`
+}
+
+// RevokeSessionDto 会话吊销事件
+type RevokeSessionDto struct {
+	Uid int64  `json:"uid"`
+	Sid string `json:"sid"`
+}
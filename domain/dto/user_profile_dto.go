@@ -0,0 +1,6 @@
+package dto
+
+// UserProfileChangedDto 用户资料变更事件
+type UserProfileChangedDto struct {
+	Uid int64 `json:"uid"`
+}
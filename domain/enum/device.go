@@ -0,0 +1,16 @@
+package enum
+
+// 登录平台
+const (
+	WebPlatform     = 1
+	DesktopPlatform = 2
+	MobilePlatform  = 3
+)
+
+// 多端登录策略
+const (
+	// DevicePolicyMulti 不限制同一平台的登录数量
+	DevicePolicyMulti = "multi"
+	// DevicePolicySingle 每个平台只保留一个会话，新登录会踢下同平台的旧会话
+	DevicePolicySingle = "single"
+)
@@ -1,7 +0,0 @@
-package enum
-
-const (
-	FriendApplyEvent = "main:FriendApplyEvent"
-	FriendNewEvent   = "main:FriendNewEvent"
-	NewMessageEvent  = "main:NewMessageEvent"
-)
@@ -0,0 +1,45 @@
+package enum
+
+import "time"
+
+const (
+	// 文件存储桶
+	MinioBucket = "diting"
+	// 下载地址，由服务端鉴权后重定向到临时下载链接
+	FileDownloadUrl = "/api/file/download?msgId=%d"
+	// 临时下载链接有效期
+	FileDownloadExpire = 5 * time.Minute
+	// 头像对象路径：avatar/用户ID/随机串
+	AvatarObjectKey = "avatar/%d/%s"
+	// 头像地址，由服务端重定向到临时下载链接
+	AvatarUrl = "/api/public/avatar?key=%s"
+)
+
+const (
+	// 默认单个图片最大 10MB
+	DefaultImgMaxSize = 10 << 20
+	// 默认头像最大 2MB
+	DefaultAvatarMaxSize = 2 << 20
+	// 默认每个用户每天最多上传 500MB
+	DefaultDailyUploadQuota = 500 << 20
+)
+
+// DefaultImgAllowedTypes 默认允许上传的图片类型
+var DefaultImgAllowedTypes = []string{"image/jpeg", "image/png", "image/gif", "image/webp"}
+
+// 文件引用方类型
+const (
+	// ObjectRefMessage 消息引用，包括图片消息和聊天记录中的图片
+	ObjectRefMessage = 1
+	// ObjectRefFavorite 收藏引用
+	ObjectRefFavorite = 2
+)
+
+const (
+	// ObjectGcDelay 文件失去所有引用后延迟删除的时长，期间新增的引用会保留文件
+	ObjectGcDelay = 10 * time.Minute
+	// ObjectGcInterval 清理文件的间隔
+	ObjectGcInterval = time.Minute
+	// ObjectGcBatchSize 每轮最多检查的文件数
+	ObjectGcBatchSize = 100
+)
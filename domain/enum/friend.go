@@ -0,0 +1,47 @@
+package enum
+
+import "time"
+
+// FriendTagSeparator 好友标签之间的分隔符
+const FriendTagSeparator = ","
+
+// ApplyTypeFriend 申请类型：加好友
+const ApplyTypeFriend = 1
+
+// 好友申请状态
+const (
+	// ApplyWaiting 待审批
+	ApplyWaiting = 1
+	// ApplyAgreed 已同意
+	ApplyAgreed = 2
+	// ApplyRejected 已拒绝
+	ApplyRejected = 3
+	// ApplyExpired 超时未处理
+	ApplyExpired = 4
+)
+
+const (
+	// DefaultApplyExpire 好友申请默认有效期
+	DefaultApplyExpire = 7 * 24 * time.Hour
+	// DefaultApplyCooldown 被拒绝或过期后默认多久才能再次申请
+	DefaultApplyCooldown = 24 * time.Hour
+	// ApplyExpireInterval 过期任务执行间隔
+	ApplyExpireInterval = time.Hour
+	// ApplyExpireBatchSize 过期任务每批处理的申请数
+	ApplyExpireBatchSize = 500
+)
+
+const (
+	// RecommendExpire 好友推荐缓存有效期，关系变化时提前失效
+	RecommendExpire = time.Hour
+	// RecommendCandidateLimit 每种来源最多取的候选人数
+	RecommendCandidateLimit = 200
+	// RecommendCacheSize 缓存的推荐人数
+	RecommendCacheSize = 50
+	// RecommendDefaultSize 默认返回的推荐人数
+	RecommendDefaultSize = 20
+	// RecommendMutualFriendWeight 每个共同好友的权重
+	RecommendMutualFriendWeight = 3
+	// RecommendSharedGroupWeight 每个共同群聊的权重
+	RecommendSharedGroupWeight = 1
+)
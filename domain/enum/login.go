@@ -0,0 +1,21 @@
+package enum
+
+import "time"
+
+const (
+	// DefaultLoginMaxFailures 同一用户名连续失败多少次后锁定
+	DefaultLoginMaxFailures = 5
+	// DefaultLoginIpMaxFailures 同一ip失败多少次后锁定，ip可能是多人共用的出口，阈值更高
+	DefaultLoginIpMaxFailures = 20
+	// DefaultLoginFailWindow 失败次数的统计窗口
+	DefaultLoginFailWindow = 15 * time.Minute
+	// DefaultLoginLockBase 首次锁定时长，之后每次失败翻倍
+	DefaultLoginLockBase = time.Minute
+	// DefaultLoginLockMax 最长锁定时长
+	DefaultLoginLockMax = time.Hour
+)
+
+// 安全事件类型
+const (
+	SecurityLoginLocked = "login_locked"
+)
@@ -1,5 +1,7 @@
 package enum
 
+import "time"
+
 const (
 	TextMessage = 1
 )
@@ -7,4 +9,101 @@ const (
 const (
 	TextMessageType = 1
 	ImgMessageType  = 3
+	// ChatHistoryMessageType 合并转发的聊天记录，extra 中保存消息快照
+	ChatHistoryMessageType = 4
+	// SystemMessageType 系统提示消息，如置顶、取消置顶
+	SystemMessageType = 5
+	// PollMessageType 投票消息，extra 中保存投票的题目和选项
+	PollMessageType = 6
+)
+
+// ExtraMessageTypes 需要把 extra 返回给客户端渲染的消息类型
+var ExtraMessageTypes = []int32{ImgMessageType, ChatHistoryMessageType, PollMessageType}
+
+// DefaultMsgEditWindow 消息发送后允许编辑的时长，可通过 chat.editWindow 配置
+const DefaultMsgEditWindow = 24 * time.Hour
+
+// 表情回应操作
+const (
+	// ReactionAdd 添加表情
+	ReactionAdd = 1
+	// ReactionRemove 取消表情
+	ReactionRemove = 2
+)
+
+// MsgReactionEmojiLimit 每条消息最多的表情种类数
+const MsgReactionEmojiLimit = 20
+
+// 转发方式
+const (
+	// ForwardSingle 逐条转发
+	ForwardSingle = 1
+	// ForwardMerge 合并转发
+	ForwardMerge = 2
+)
+
+const (
+	// ForwardSingleLimit 逐条转发最多的消息数
+	ForwardSingleLimit = 30
+	// ChatHistoryContent 聊天记录消息的展示内容
+	ChatHistoryContent = "[聊天记录]"
+	// ChatHistoryMaxSize 合并转发生成的聊天记录序列化后的最大字节数
+	ChatHistoryMaxSize = 64 * 1024
+)
+
+// 置顶操作
+const (
+	// MsgPin 置顶
+	MsgPin = 1
+	// MsgUnpin 取消置顶
+	MsgUnpin = 2
+)
+
+// DefaultRoomPinLimit 每个房间最多的置顶消息数，可通过 chat.pinLimit 配置
+const DefaultRoomPinLimit = 10
+
+const (
+	// FavoriteTagSeparator 收藏标签之间的分隔符
+	FavoriteTagSeparator = ","
+	// FavoriteDefaultPageSize 收藏列表默认每页条数
+	FavoriteDefaultPageSize = 20
+)
+
+// 定时消息状态
+const (
+	ScheduledPending   = 1
+	ScheduledSent      = 2
+	ScheduledCancelled = 3
+	ScheduledFailed    = 4
+)
+
+const (
+	// ScheduledMsgMaxAhead 定时消息最远可以预约的时长
+	ScheduledMsgMaxAhead = 30 * 24 * time.Hour
+	// ScheduledMsgMinAhead 计划时间早于该时长的消息直接发送
+	ScheduledMsgMinAhead = 5 * time.Second
+	// ScheduledMsgLimit 每个用户最多的待发送定时消息数
+	ScheduledMsgLimit = 100
+	// ScheduledMsgInterval 扫描到期定时消息的间隔
+	ScheduledMsgInterval = time.Second
+	// ScheduledMsgBatchSize 每次投递的定时消息数
+	ScheduledMsgBatchSize = 100
+	// ScheduledMsgRetryDelay 投递遇到临时错误时重新调度的延迟
+	ScheduledMsgRetryDelay = 10 * time.Second
+)
+
+const (
+	// MsgExpireInterval 清理自毁消息的间隔
+	MsgExpireInterval = time.Minute
+	// MsgExpireBatchSize 每批清理的自毁消息数
+	MsgExpireBatchSize = 500
+)
+
+const (
+	// PollMinDuration 投票最短的持续时间
+	PollMinDuration = time.Minute
+	// PollMaxDuration 投票最长的持续时间
+	PollMaxDuration = 30 * 24 * time.Hour
+	// PollContentPrefix 投票消息的展示内容前缀
+	PollContentPrefix = "[投票] "
 )
@@ -8,9 +8,17 @@ const (
 	User       = Project + "user:"
 	UserFriend = Project + "userFriend:"
 	UserApply  = Project + "userApply:"
+	UserBlock  = Project + "userBlock:"
 	RoomFriend = Project + "roomFriend:"
 	Contact    = Project + "contact:"
 	Room       = Project + "room:"
+	Upload     = Project + "upload:"
+	Token      = Project + "token:"
+	Login      = Project + "login:"
+	RateLimit  = Project + "rateLimit:"
+	Recommend  = Project + "recommend:"
+	Scheduled  = Project + "scheduled:"
+	Object     = Project + "object:"
 )
 const (
 	// 房间缓存
@@ -30,6 +38,40 @@ const (
 	// 好友申请缓存
 	UserApplyCacheByUidAndFriendUid = UserApply + "%d_%d"
 
+	// 用户拉黑的uid列表缓存
+	UserBlockCacheByUid = UserBlock + "%d"
+
 	// 会话缓存
 	ContactCacheById = Contact + "%d"
+
+	// 用户每日上传用量 uid_日期
+	UserUploadQuota = Upload + "quota:%d_%s"
+
+	// 登录会话 sid
+	TokenSession = Token + "session:%s"
+	// 刷新令牌摘要到会话的映射
+	TokenRefresh = Token + "refresh:%s"
+	// 用户的所有会话 uid
+	TokenUserSessions = Token + "user:%d"
+	// 扫码登录票据
+	TokenQrLogin = Token + "qrlogin:%s"
+
+	// 登录失败次数
+	LoginFailByName = Login + "fail:name:%s"
+	LoginFailByIp   = Login + "fail:ip:%s"
+	// 登录锁定
+	LoginLockByName = Login + "lock:name:%s"
+	LoginLockByIp   = Login + "lock:ip:%s"
+
+	// 限流令牌桶 规则名_限流对象
+	RateLimitBucket = RateLimit + "%s_%s"
+
+	// 好友推荐 uid
+	UserRecommendCache = Recommend + "%d"
+
+	// 待发送定时消息的有序集合，分数为计划发送时间的毫秒时间戳
+	ScheduledMsgZSet = Scheduled + "msg"
+
+	// 待清理文件的有序集合，分数为可以删除的毫秒时间戳
+	ObjectGcZSet = Object + "gc"
 )
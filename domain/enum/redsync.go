@@ -1,7 +1,17 @@
 package enum
 
 const (
-	Lock              = "lock:"
-	UserLock          = Lock + "diting-user:"
-	UserAndFriendLock = UserLock + "%d_%d"
+	Lock               = "lock:"
+	UserLock           = Lock + "diting-user:"
+	UserAndFriendLock  = UserLock + "%d_%d"
+	OutboxLock         = Lock + "diting-outbox"
+	ApplyExpireLock    = Lock + "diting-apply-expire"
+	PinyinBackfillLock = Lock + "diting-pinyin-backfill"
+	MsgReactionLock    = Lock + "diting-msg-reaction:%d"
+	RoomPinLock        = Lock + "diting-room-pin:%d"
+	MsgExpireLock      = Lock + "diting-msg-expire"
+	PollVoteLock       = Lock + "diting-poll-vote:%d:%d"
+	SessionLock        = Lock + "diting-session:%s"
+	QrLoginLock        = Lock + "diting-qrlogin:%s"
+	UserNameLock       = Lock + "diting-username:%s"
 )
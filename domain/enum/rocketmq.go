@@ -1,9 +1,16 @@
 package enum
 
 const (
-	UserLoginTopic    = "diting-login"
-	NewFriendTopic    = "diting-new-friend"
-	NewMessageTopic   = "diting-new-message"
-	DeleteFriendTopic = "diting-delete-friend"
-	FriendApplyTopic  = "diting-friend-apply"
+	UserLoginTopic      = "diting-login"
+	NewFriendTopic      = "diting-new-friend"
+	NewMessageTopic     = "diting-new-message"
+	DeleteFriendTopic   = "diting-delete-friend"
+	FriendApplyTopic    = "diting-friend-apply"
+	SessionRevokedTopic = "diting-session-revoked"
+	SecurityEventTopic  = "diting-security-event"
+	UserProfileTopic    = "diting-user-profile"
+	MessageEditTopic    = "diting-message-edit"
+	MsgReactionTopic    = "diting-message-reaction"
+	MsgPinTopic         = "diting-message-pin"
+	PollVoteTopic       = "diting-poll-vote"
 )
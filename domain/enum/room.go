@@ -8,3 +8,10 @@ const (
 	GROUP    = 1
 	PERSONAL = 2
 )
+
+// 群成员角色
+const (
+	GroupOwner  = 1
+	GroupAdmin  = 2
+	GroupNormal = 3
+)
@@ -0,0 +1,29 @@
+package enum
+
+import "time"
+
+const (
+	// DefaultAccessTokenExpire 访问令牌默认有效期
+	DefaultAccessTokenExpire = 15 * time.Minute
+	// DefaultRefreshTokenExpire 刷新令牌默认有效期
+	DefaultRefreshTokenExpire = 30 * 24 * time.Hour
+)
+
+const (
+	// QrLoginExpire 扫码登录票据有效期
+	QrLoginExpire = 2 * time.Minute
+	// QrLoginPollTimeout 长轮询单次最长等待时间
+	QrLoginPollTimeout = 25 * time.Second
+	// QrLoginPollInterval 长轮询检查票据状态的间隔
+	QrLoginPollInterval = 500 * time.Millisecond
+)
+
+// 扫码登录票据状态
+const (
+	// QrLoginWaiting 等待手机确认
+	QrLoginWaiting = 1
+	// QrLoginConfirmed 手机已确认，等待网页端领取令牌
+	QrLoginConfirmed = 2
+	// QrLoginExpired 票据已过期或已被领取
+	QrLoginExpired = 3
+)
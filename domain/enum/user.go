@@ -0,0 +1,16 @@
+package enum
+
+// 是否允许通过昵称搜索
+const (
+	// Discoverable 允许
+	Discoverable = 1
+	// Undiscoverable 不允许，只能通过uid精确查找
+	Undiscoverable = 2
+)
+
+const (
+	// UserSearchDefaultPageSize 搜索用户默认每页条数
+	UserSearchDefaultPageSize = 10
+	// PinyinBackfillBatchSize 补全昵称拼音每批处理的用户数
+	PinyinBackfillBatchSize = 500
+)
@@ -12,6 +12,8 @@ func (msg Message) GetContactMsg() string {
 		return msg.Content
 	} else if msg.Type == enum.ImgMessageType {
 		return "[图片]"
+	} else if msg.Type == enum.ChatHistoryMessageType {
+		return enum.ChatHistoryContent
 	}
 	return msg.Content
 }
@@ -0,0 +1,9 @@
+package req
+
+type BlockUserReq struct {
+	Uid int64 `json:"uid" binding:"required"`
+}
+
+type UnblockUserReq struct {
+	Uid int64 `uri:"uid" binding:"required"`
+}
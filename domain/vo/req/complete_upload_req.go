@@ -0,0 +1,6 @@
+package req
+
+type CompleteUploadReq struct {
+	// 消息ID
+	MsgId int64 `json:"msgId" binding:"required"`
+}
@@ -0,0 +1,8 @@
+package req
+
+type DownloadFileReq struct {
+	// 消息ID
+	MsgId int64 `json:"msgId" form:"msgId" binding:"required"`
+	// 聊天记录中的消息下标，下载聊天记录里的文件时传入
+	Index *int `json:"index" form:"index" binding:"omitempty,min=0"`
+}
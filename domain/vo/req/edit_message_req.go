@@ -0,0 +1,6 @@
+package req
+
+type EditMessageReq struct {
+	MsgId   int64  `json:"msgId" form:"msgId" binding:"required"`
+	Content string `json:"content" form:"content" binding:"required,max=1024"`
+}
@@ -0,0 +1,34 @@
+package req
+
+type AddFavoriteReq struct {
+	MsgId int64    `json:"msgId" binding:"required"`
+	Tags  []string `json:"tags" binding:"max=10,dive,required,max=16,excludes=0x2C"` // 标签
+	Note  string   `json:"note" binding:"max=255"`                                   // 备注
+}
+
+type UpdateFavoriteReq struct {
+	Id   int64    `json:"id" binding:"required"`
+	Tags []string `json:"tags" binding:"max=10,dive,required,max=16,excludes=0x2C"` // 标签，传空数组清除标签
+	Note string   `json:"note" binding:"max=255"`                                   // 备注，传空字符串清除备注
+}
+
+type DeleteFavoriteReq struct {
+	Id int64 `uri:"id" binding:"required"`
+}
+
+type GetFavoriteListReq struct {
+	// 游标，首页不传
+	Cursor *string `form:"cursor"`
+	// 每页条数，不传使用默认值
+	PageSize int `form:"pageSize" binding:"omitempty,min=1,max=50"`
+	// 按内容、备注或发送者昵称搜索
+	Keyword string `form:"keyword" binding:"max=32"`
+	// 按标签筛选
+	Tag string `form:"tag"`
+}
+
+type FavoriteDownloadReq struct {
+	Id int64 `form:"id" binding:"required"`
+	// 聊天记录中的消息下标，下载聊天记录里的文件时传入
+	Index *int `form:"index" binding:"omitempty,min=0"`
+}
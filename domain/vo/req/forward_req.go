@@ -0,0 +1,10 @@
+package req
+
+type ForwardReq struct {
+	// 被转发的消息，需来自同一个房间
+	MsgIds []int64 `json:"msgIds" form:"msgIds" binding:"required,min=1,max=100,dive,required"`
+	// 目标房间
+	RoomIds []int64 `json:"roomIds" form:"roomIds" binding:"required,min=1,max=9,dive,required"`
+	// 1逐条转发 2合并转发
+	Type int32 `json:"type" form:"type" binding:"required,oneof=1 2"`
+}
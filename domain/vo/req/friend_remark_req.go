@@ -0,0 +1,14 @@
+package req
+
+import pkgReq "DiTing-Go/pkg/domain/vo/req"
+
+type UpdateFriendRemarkReq struct {
+	FriendUid int64    `json:"friendUid" binding:"required"`
+	Remark    string   `json:"remark" binding:"max=32"`                                  // 备注，传空字符串清除备注
+	Tags      []string `json:"tags" binding:"max=10,dive,required,max=16,excludes=0x2C"` // 标签，传空数组清除标签
+}
+
+type GetFriendListReq struct {
+	pkgReq.PageReq
+	Tag string `form:"tag"` // 按标签筛选
+}
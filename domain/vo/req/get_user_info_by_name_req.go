@@ -1,6 +1,10 @@
 package req
 
 type GetUserInfoByNameReq struct {
-	// 用户名
-	Name string `form:"name" binding:"required"`
+	// 搜索关键字，支持uid精确查找、昵称和拼音模糊匹配
+	Name string `form:"name" binding:"required,max=20"`
+	// 游标，首页不传
+	Cursor *string `form:"cursor"`
+	// 每页条数，不传使用默认值
+	PageSize int `form:"pageSize" binding:"omitempty,min=1,max=50"`
 }
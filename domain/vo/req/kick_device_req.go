@@ -0,0 +1,5 @@
+package req
+
+type KickDeviceReq struct {
+	ID int64 `uri:"id" binding:"required"`
+}
@@ -6,6 +6,10 @@ type MessageBody struct {
 }
 type MessageReq struct {
 	RoomId  int64       `json:"roomId" form:"roomId" binding:"required"`
-	MsgType int32       `json:"msgType" form:"msgType" binding:"required"`
+	MsgType int32       `json:"msgType" form:"msgType" binding:"required,oneof=1"` // 只能发送文本消息，图片、聊天记录和投票走各自的接口
 	Body    MessageBody `json:"body" form:"body" binding:"required"`
+	// 客户端生成的消息ID(UUID)，用于重试时去重
+	ClientMsgId string `json:"clientMsgId" form:"clientMsgId" binding:"omitempty,uuid"`
+	// 计划发送时间 毫秒时间戳，不传立即发送
+	SendAt int64 `json:"sendAt" form:"sendAt" binding:"omitempty,min=0"`
 }
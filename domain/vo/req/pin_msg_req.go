@@ -0,0 +1,13 @@
+package req
+
+type PinMsgReq struct {
+	MsgId int64 `json:"msgId" binding:"required"`
+}
+
+type UnpinMsgReq struct {
+	MsgId int64 `uri:"msgId" binding:"required"`
+}
+
+type GetPinnedMsgReq struct {
+	RoomId int64 `form:"roomId" binding:"required"`
+}
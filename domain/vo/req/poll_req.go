@@ -0,0 +1,23 @@
+package req
+
+type CreatePollReq struct {
+	RoomId   int64    `json:"roomId" form:"roomId" binding:"required"`
+	Question string   `json:"question" form:"question" binding:"required,max=100"`
+	Options  []string `json:"options" form:"options" binding:"required,min=2,max=10,dive,required,max=50"`
+	// 是否多选
+	Multi bool `json:"multi" form:"multi"`
+	// 是否匿名
+	Anonymous bool `json:"anonymous" form:"anonymous"`
+	// 截止时间 毫秒时间戳
+	Deadline int64 `json:"deadline" form:"deadline" binding:"required"`
+}
+
+type VotePollReq struct {
+	MsgId int64 `json:"msgId" form:"msgId" binding:"required"`
+	// 选中的选项下标，重新投票时覆盖之前的选择
+	Options []int32 `json:"options" form:"options" binding:"required,min=1,max=10,dive,min=0"`
+}
+
+type GetPollReq struct {
+	MsgId int64 `json:"msgId" form:"msgId" binding:"required"`
+}
@@ -0,0 +1,14 @@
+package req
+
+type CreateQrLoginReq struct {
+	Platform   int32  `json:"platform" binding:"omitempty,oneof=1 2"` // 登录平台 1网页 2桌面，默认网页
+	DeviceName string `json:"deviceName" binding:"max=64"`            // 设备名称
+}
+
+type PollQrLoginReq struct {
+	Ticket string `form:"ticket" binding:"required"`
+}
+
+type ConfirmQrLoginReq struct {
+	Ticket string `json:"ticket" binding:"required"`
+}
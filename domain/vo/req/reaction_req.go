@@ -0,0 +1,8 @@
+package req
+
+type ReactionReq struct {
+	MsgId int64  `json:"msgId" form:"msgId" binding:"required"`
+	Emoji string `json:"emoji" form:"emoji" binding:"required,max=32"`
+	// 1添加 2取消
+	Action int32 `json:"action" form:"action" binding:"required,oneof=1 2"`
+}
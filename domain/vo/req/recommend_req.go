@@ -0,0 +1,5 @@
+package req
+
+type RecommendReq struct {
+	Size int `form:"size" binding:"omitempty,min=1,max=50"`
+}
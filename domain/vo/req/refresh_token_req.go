@@ -0,0 +1,5 @@
+package req
+
+type RefreshTokenReq struct {
+	RefreshToken string `json:"refreshToken" binding:"required"`
+}
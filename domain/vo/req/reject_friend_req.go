@@ -0,0 +1,5 @@
+package req
+
+type RejectFriendReq struct {
+	Uid int64 `json:"uid" binding:"required"`
+}
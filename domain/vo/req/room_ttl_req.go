@@ -0,0 +1,7 @@
+package req
+
+type UpdateRoomTtlReq struct {
+	RoomId int64 `json:"roomId" binding:"required"`
+	// 消息自毁时长(秒)，0关闭 3600一小时 86400一天 604800七天
+	Ttl int32 `json:"ttl" binding:"oneof=0 3600 86400 604800"`
+}
@@ -0,0 +1,5 @@
+package req
+
+type CancelScheduledMsgReq struct {
+	Id int64 `uri:"id" binding:"required"`
+}
@@ -0,0 +1,19 @@
+package req
+
+// UpdateProfileReq 修改个人资料，未传的字段保持不变
+type UpdateProfileReq struct {
+	Name         *string `json:"name" binding:"omitempty,min=1,max=20"`      // 昵称
+	Avatar       *string `json:"avatar" binding:"omitempty,max=255"`         // 头像地址，由头像上传接口返回，传空字符串清除头像
+	Sex          *int32  `json:"sex" binding:"omitempty,oneof=1 2"`          // 性别 1为男性，2为女性
+	Signature    *string `json:"signature" binding:"omitempty,max=100"`      // 个性签名
+	Discoverable *int32  `json:"discoverable" binding:"omitempty,oneof=1 2"` // 是否允许通过昵称搜索 1允许 2不允许
+}
+
+type AvatarPreSignedReq struct {
+	ContentType string `form:"contentType" binding:"required"`
+	Size        int64  `form:"size" binding:"required"`
+}
+
+type AvatarReq struct {
+	Key string `form:"key" binding:"required"`
+}
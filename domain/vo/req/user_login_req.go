@@ -1,6 +1,8 @@
 package req
 
 type UserLoginReq struct {
-	UserName string `json:"username" binding:"required"`
-	Password string `json:"password" binding:"required"`
+	UserName   string `json:"username" binding:"required"`
+	Password   string `json:"password" binding:"required"`
+	Platform   int32  `json:"platform" binding:"omitempty,oneof=1 2 3"` // 登录平台 1网页 2桌面 3移动，默认网页
+	DeviceName string `json:"deviceName" binding:"max=64"`              // 设备名称
 }
@@ -2,5 +2,5 @@ package req
 
 type UserRegisterReq struct {
 	Username string `json:"username" binding:"required"`
-	Password string `json:"password" binding:"required"`
+	Password string `json:"password" binding:"required,max=72"`
 }
@@ -0,0 +1,10 @@
+package resp
+
+import "time"
+
+type BlockUserResp struct {
+	Uid       int64     `json:"uid"`
+	Name      string    `json:"name"`
+	Avatar    string    `json:"avatar"`
+	BlockTime time.Time `json:"blockTime"`
+}
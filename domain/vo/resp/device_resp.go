@@ -0,0 +1,14 @@
+package resp
+
+import "time"
+
+type DeviceResp struct {
+	ID           int64     `json:"id"`
+	Platform     int32     `json:"platform"`
+	Name         string    `json:"name"`
+	Ip           string    `json:"ip"`
+	LastSeenTime time.Time `json:"lastSeenTime"`
+	CreateTime   time.Time `json:"createTime"`
+	// 是否为当前设备
+	Current bool `json:"current"`
+}
@@ -0,0 +1,20 @@
+package resp
+
+import "encoding/json"
+
+type FavoriteResp struct {
+	Id       int64   `json:"id"`
+	MsgId    int64   `json:"msgId"`
+	RoomId   int64   `json:"roomId"`
+	FromUser MsgUser `json:"fromUser"`
+	Type     int32   `json:"type"`
+	Content  string  `json:"content"`
+	// 聊天记录消息的快照
+	Extra json.RawMessage `json:"extra,omitempty"`
+	Tags  []string        `json:"tags"`
+	Note  string          `json:"note"`
+	// 消息发送时间 时间戳格式
+	SendTime int64 `json:"sendTime"`
+	// 收藏时间 时间戳格式
+	FavoriteTime int64 `json:"favoriteTime"`
+}
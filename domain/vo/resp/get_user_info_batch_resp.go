@@ -4,5 +4,7 @@ type GetUserInfoBatchResp struct {
 	Uid         int64  `json:"uid"`
 	Username    string `json:"name"`
 	Avatar      string `json:"avatar"`
+	Sex         int32  `json:"sex"`
+	Signature   string `json:"signature"`
 	NeedRefresh bool   `json:"needRefresh"`
 }
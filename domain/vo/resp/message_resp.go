@@ -1,22 +1,45 @@
 package resp
 
+import "encoding/json"
+
 type MsgUser struct {
 	Uid      int64  `json:"uid"`
 	Username string `json:"username"`
 	Avatar   string `json:"avatar"`
 }
 type Msg struct {
-	ID     int64    `json:"id"`
-	RoomId int64    `json:"roomId"`
-	Type   int32    `json:"type"`
-	Body   TextBody `json:"body"`
+	ID          int64    `json:"id"`
+	RoomId      int64    `json:"roomId"`
+	Type        int32    `json:"type"`
+	Body        TextBody `json:"body"`
+	ClientMsgId string   `json:"clientMsgId,omitempty"`
+	// 是否转发的消息
+	Forwarded bool `json:"forwarded,omitempty"`
+	// 是否被编辑过
+	Edited bool `json:"edited,omitempty"`
+	// 最后编辑时间 时间戳格式
+	EditTime int64 `json:"editTime,omitempty"`
+	// 自毁时间 时间戳格式，不自毁时为空
+	ExpireTime int64 `json:"expireTime,omitempty"`
+	// 表情回应
+	Reactions []ReactionResp `json:"reactions,omitempty"`
+}
+type ReactionResp struct {
+	Emoji string `json:"emoji"`
+	Count int64  `json:"count"`
+	// 当前用户是否回应过
+	Reacted bool `json:"reacted"`
 }
 type TextBody struct {
 	Content string `json:"content"`
 	Reply   int64  `json:"reply"`
+	// 聊天记录消息的快照，投票消息的题目和选项
+	Extra json.RawMessage `json:"extra,omitempty"`
 }
 type MessageResp struct {
 	FromUser MsgUser `json:"fromUser"`
 	Message  Msg     `json:"message"`
 	SendTime int64   `json:"sendTime"`
+	// 发送者已被当前用户拉黑，客户端隐藏该消息
+	Blocked bool `json:"blocked,omitempty"`
 }
@@ -0,0 +1,9 @@
+package resp
+
+type PinnedMsgResp struct {
+	MessageResp
+	// 置顶操作人
+	PinUid int64 `json:"pinUid"`
+	// 置顶时间 时间戳格式
+	PinTime int64 `json:"pinTime"`
+}
@@ -0,0 +1,25 @@
+package resp
+
+type PollResp struct {
+	MsgId     int64  `json:"msgId"`
+	RoomId    int64  `json:"roomId"`
+	Question  string `json:"question"`
+	Multi     bool   `json:"multi"`
+	Anonymous bool   `json:"anonymous"`
+	// 截止时间 时间戳格式
+	Deadline int64 `json:"deadline"`
+	// 是否已截止，截止后的结果为最终结果
+	Closed bool `json:"closed"`
+	// 参与投票的人数
+	VoterCount int64            `json:"voterCount"`
+	Options    []PollOptionResp `json:"options"`
+}
+type PollOptionResp struct {
+	Index int32  `json:"index"`
+	Text  string `json:"text"`
+	Count int64  `json:"count"`
+	// 当前用户是否选择了该选项
+	Voted bool `json:"voted"`
+	// 投票人，匿名投票为空
+	Voters []int64 `json:"voters,omitempty"`
+}
@@ -4,3 +4,10 @@ type PreSignedResp struct {
 	Url    string            `json:"url"`
 	Policy map[string]string `json:"policy"`
 }
+
+type AvatarPreSignedResp struct {
+	Url    string            `json:"url"`
+	Policy map[string]string `json:"policy"`
+	// 上传完成后作为修改资料的头像地址
+	Avatar string `json:"avatar"`
+}
@@ -0,0 +1,11 @@
+package resp
+
+type CreateQrLoginResp struct {
+	Ticket    string `json:"ticket"`    // 二维码内容
+	ExpiresIn int64  `json:"expiresIn"` // 票据有效期，单位秒
+}
+
+type PollQrLoginResp struct {
+	Status int            `json:"status"`          // 票据状态 1等待确认 2已确认 3已过期
+	Login  *UserLoginResp `json:"login,omitempty"` // 已确认时返回登录结果
+}
@@ -0,0 +1,9 @@
+package resp
+
+type RecommendResp struct {
+	Uid           int64  `json:"uid"`           // 用户ID
+	Name          string `json:"name"`          // 昵称
+	Avatar        string `json:"avatar"`        // 头像
+	MutualFriends int64  `json:"mutualFriends"` // 共同好友数
+	SharedGroups  int64  `json:"sharedGroups"`  // 共同群聊数
+}
@@ -0,0 +1,13 @@
+package resp
+
+type ScheduledMsgResp struct {
+	Id          int64    `json:"id"`
+	RoomId      int64    `json:"roomId"`
+	Type        int32    `json:"type"`
+	Body        TextBody `json:"body"`
+	ClientMsgId string   `json:"clientMsgId,omitempty"`
+	// 计划发送时间 毫秒时间戳
+	SendAt int64 `json:"sendAt"`
+	// 1待发送 2已发送 3已取消 4发送失败
+	Status int32 `json:"status"`
+}
@@ -1,7 +1,9 @@
 package resp
 
 type UserContactResp struct {
-	Uid          int64 `json:"uid"`          // 用户ID
-	ActiveStatus int   `json:"activeStatus"` // 用户状态
-	LastOptTime  int64 `json:"lastOptTime"`  // 最后操作时间
+	Uid          int64    `json:"uid"`          // 用户ID
+	ActiveStatus int      `json:"activeStatus"` // 用户状态
+	LastOptTime  int64    `json:"lastOptTime"`  // 最后操作时间
+	Remark       string   `json:"remark"`       // 备注
+	Tags         []string `json:"tags"`         // 标签
 }
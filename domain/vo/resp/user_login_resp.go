@@ -1,8 +1,16 @@
 package resp
 
 type UserLoginResp struct {
-	Token  string `json:"token"`
-	Uid    int64  `json:"uid"`
-	Name   string `json:"name"`
-	Avatar string `json:"avatar"`
+	Token        string `json:"token"`
+	RefreshToken string `json:"refreshToken"`
+	ExpiresIn    int64  `json:"expiresIn"` // 访问令牌有效期，单位秒
+	Uid          int64  `json:"uid"`
+	Name         string `json:"name"`
+	Avatar       string `json:"avatar"`
+}
+
+type RefreshTokenResp struct {
+	Token        string `json:"token"`
+	RefreshToken string `json:"refreshToken"`
+	ExpiresIn    int64  `json:"expiresIn"` // 访问令牌有效期，单位秒
 }
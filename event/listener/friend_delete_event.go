@@ -9,50 +9,25 @@ import (
 	"DiTing-Go/pkg/utils"
 	"DiTing-Go/utils/jsonUtils"
 	"DiTing-Go/utils/redisCache"
+	wsEnum "DiTing-Go/websocket/domain/enum"
 	"context"
 	"fmt"
-	"github.com/apache/rocketmq-client-go/v2"
-	"github.com/apache/rocketmq-client-go/v2/consumer"
-	"github.com/apache/rocketmq-client-go/v2/primitive"
 	"github.com/pkg/errors"
-	"github.com/spf13/viper"
 	"sort"
 )
 
-func init() {
-	host := viper.GetString("rocketmq.host")
-	// 设置推送消费者
-	rocketConsumer, _ := rocketmq.NewPushConsumer(
-		//消费组
-		consumer.WithGroupName(enum.DeleteFriendTopic),
-		// namesrv地址
-		consumer.WithNameServer([]string{host}),
-	)
-	err := rocketConsumer.Subscribe(enum.DeleteFriendTopic, consumer.MessageSelector{}, deleteFriendEvent)
-	if err != nil {
-		global.Logger.Panicf("subscribe error: %s", err.Error())
-	}
-	err = rocketConsumer.Start()
-	if err != nil {
-		global.Logger.Panicf("start consumer error: %s", err.Error())
+func deleteFriendEvent(ctx context.Context, body []byte) error {
+	// 解码
+	deleteFriendDto := dto.DeleteFriendDto{}
+	if err := jsonUtils.UnmarshalMsg(&deleteFriendDto, body); err != nil {
+		return err
 	}
-}
 
-func deleteFriendEvent(ctx context.Context, ext ...*primitive.MessageExt) (consumer.ConsumeResult, error) {
-	for i := range ext {
-		// 解码
-		deleteFriendDto := dto.DeleteFriendDto{}
-		if err := jsonUtils.UnmarshalMsg(&deleteFriendDto, ext[i]); err != nil {
-			global.Logger.Errorf("jsonUtils unmarshal error: %s", err.Error())
-			return consumer.ConsumeRetryLater, nil
-		}
-
-		if err := deleteFriend(deleteFriendDto); err != nil {
-			global.Logger.Errorf("deleteFriend error: %s", err.Error())
-			return consumer.ConsumeRetryLater, nil
-		}
+	if err := deleteFriend(deleteFriendDto); err != nil {
+		global.Logger.Errorf("deleteFriend error: %s", err.Error())
+		return err
 	}
-	return consumer.ConsumeSuccess, nil
+	return nil
 }
 
 func deleteFriend(deleteFriendDto dto.DeleteFriendDto) error {
@@ -156,6 +131,9 @@ func deleteFriend(deleteFriendDto dto.DeleteFriendDto) error {
 		global.Logger.Errorf("事务提交失败 %s", err.Error())
 		return errors.New("Business Error")
 	}
+	redisCache.RemoveUserRecommend(uid, deleteFriendUid)
+	// 通知被删除的一方移除好友和会话
+	pushFriendEvent(deleteFriendUid, wsEnum.FriendDeleted, uid, "")
 
 	return nil
 }
@@ -8,57 +8,29 @@ import (
 	"DiTing-Go/pkg/utils"
 	"DiTing-Go/service"
 	"DiTing-Go/utils/redisCache"
+	wsEnum "DiTing-Go/websocket/domain/enum"
 	"context"
 	"fmt"
-	"github.com/apache/rocketmq-client-go/v2"
-	"github.com/apache/rocketmq-client-go/v2/consumer"
-	"github.com/apache/rocketmq-client-go/v2/primitive"
 	"github.com/goccy/go-json"
 	"github.com/pkg/errors"
-	"github.com/spf13/viper"
 	"gorm.io/gorm"
 	"sort"
 	"strconv"
 	"time"
 )
 
-func init() {
-	host := viper.GetString("rocketmq.host")
-	// 设置推送消费者
-	rocketConsumer, _ := rocketmq.NewPushConsumer(
-		//消费组
-		consumer.WithGroupName(enum.UserLoginTopic),
-		// namesrv地址
-		consumer.WithNameServer([]string{host}),
-	)
-	err := rocketConsumer.Subscribe(enum.NewFriendTopic, consumer.MessageSelector{}, friendNewEvent)
-	if err != nil {
-		global.Logger.Panicf("subscribe error: %s", err.Error())
-	}
-	err = rocketConsumer.Start()
-	if err != nil {
-		global.Logger.Panicf("start consumer error: %s", err.Error())
+func friendNewEvent(ctx context.Context, body []byte) error {
+	// 解码
+	userFriend := model.UserFriend{}
+	if err := json.Unmarshal(body, &userFriend); err != nil {
+		global.Logger.Errorf("json unmarshal error: %s", err.Error())
+		return err
 	}
-}
-
-func friendNewEvent(ctx context.Context, ext ...*primitive.MessageExt) (consumer.ConsumeResult, error) {
-	for i := range ext {
-		// 解码
-		userFriend := model.UserFriend{}
-		userFriendMsgByte := ext[i].Message.Body
-		err := json.Unmarshal(userFriendMsgByte, &userFriend)
-		if err != nil {
-			global.Logger.Errorf("json unmarshal error: %s", err.Error())
-			return consumer.ConsumeRetryLater, nil
-		}
-		err = friendNew(userFriend)
-		if err != nil {
-			global.Logger.Errorf("friendNew error: %s", err.Error())
-			return consumer.ConsumeRetryLater, nil
-		}
-
+	if err := friendNew(userFriend); err != nil {
+		global.Logger.Errorf("friendNew error: %s", err.Error())
+		return err
 	}
-	return consumer.ConsumeSuccess, nil
+	return nil
 }
 
 func friendNew(userFriend model.UserFriend) error {
@@ -161,7 +133,7 @@ func friendNew(userFriend model.UserFriend) error {
 		Type:         enum.TextMessage,
 		Extra:        "{}",
 	}
-	if err := service.SendTextMsg(&newMsg); err != nil {
+	if err := service.SendTextMsgTx(tx, &newMsg); err != nil {
 		if err := tx.Rollback(); err != nil {
 			global.Logger.Errorf("事务回滚失败 %s", err.Error())
 			return err
@@ -201,17 +173,13 @@ func friendNew(userFriend model.UserFriend) error {
 		global.Logger.Errorf("创建会话失败 %s", err.Error())
 		return err
 	}
-	// 提交
+	// 提交，新消息事件随事务一起写入发件箱
 	if err := tx.Commit(); err != nil {
 		global.Logger.Errorf("事务提交失败 %s", err.Error())
 		return err
 	}
-	// 发送新消息事件
-	newMsgByte, _ := json.Marshal(newMsg)
-	msg := &primitive.Message{
-		Topic: enum.NewMessageTopic,
-		Body:  newMsgByte,
-	}
-	_, _ = global.RocketProducer.SendSync(ctx, msg)
+	redisCache.RemoveUserRecommend(userFriend.UID, userFriend.FriendUID)
+	// 通知申请人好友申请已通过
+	pushFriendEvent(userFriend.FriendUID, wsEnum.FriendApplyAccepted, userFriend.UID, "")
 	return nil
 }
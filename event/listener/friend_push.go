@@ -0,0 +1,42 @@
+package listener
+
+import (
+	"DiTing-Go/dal/model"
+	"DiTing-Go/domain/enum"
+	"DiTing-Go/global"
+	"DiTing-Go/pkg/utils"
+	wsResp "DiTing-Go/websocket/domain/vo/resp"
+	"DiTing-Go/websocket/service"
+	"context"
+	"fmt"
+	"github.com/goccy/go-json"
+)
+
+// pushFriendEvent 向用户推送好友相关的websocket事件，fromUid 为事件中的对方用户
+// 推送只是在线提醒，失败时只记录日志，不影响事件消费
+func pushFriendEvent(toUid int64, eventType int, fromUid int64, msg string) {
+	user := global.Query.User
+	userQ := user.WithContext(context.Background())
+	fun := func() (interface{}, error) {
+		return userQ.Where(user.ID.Eq(fromUid)).First()
+	}
+	userR := model.User{}
+	if err := utils.GetData(fmt.Sprintf(enum.UserCacheByID, fromUid), &userR, fun); err != nil {
+		global.Logger.Errorf("查询用户失败 %s", err)
+		return
+	}
+	str, err := json.Marshal(wsResp.FriendEventResp{
+		Type:   eventType,
+		Uid:    fromUid,
+		Name:   userR.Name,
+		Avatar: userR.Avatar,
+		Msg:    msg,
+	})
+	if err != nil {
+		global.Logger.Errorf("json序列化失败 %s", err)
+		return
+	}
+	if err := service.Send(toUid, str); err != nil {
+		global.Logger.Errorf("推送好友事件失败 %s", err)
+	}
+}
@@ -0,0 +1,44 @@
+package listener
+
+import (
+	"DiTing-Go/domain/enum"
+	"DiTing-Go/utils/mq"
+)
+
+// subscription 一个主题的订阅配置
+type subscription struct {
+	topic   string
+	group   string
+	handler mq.Handler
+	// 广播消费，每个实例都会收到
+	broadcast bool
+}
+
+var subscriptions = []subscription{
+	{topic: enum.FriendApplyTopic, group: enum.FriendApplyTopic, handler: friendApplyEvent},
+	{topic: enum.NewFriendTopic, group: enum.NewFriendTopic, handler: friendNewEvent},
+	{topic: enum.DeleteFriendTopic, group: enum.DeleteFriendTopic, handler: deleteFriendEvent},
+	{topic: enum.NewMessageTopic, group: enum.NewMessageTopic + "-send-message", handler: UpdateContactEvent},
+	{topic: enum.SessionRevokedTopic, handler: sessionRevokedEvent, broadcast: true},
+	{topic: enum.UserProfileTopic, group: enum.UserProfileTopic, handler: userProfileEvent},
+	{topic: enum.MessageEditTopic, group: enum.MessageEditTopic, handler: msgEditEvent},
+	{topic: enum.MsgReactionTopic, group: enum.MsgReactionTopic, handler: msgReactionEvent},
+	{topic: enum.MsgPinTopic, group: enum.MsgPinTopic, handler: msgPinEvent},
+	{topic: enum.PollVoteTopic, group: enum.PollVoteTopic, handler: pollVoteEvent},
+}
+
+// Register 向订阅者注册所有事件监听，注册完成后由调用方启动订阅者
+func Register(subscriber mq.Subscriber) error {
+	for _, s := range subscriptions {
+		var err error
+		if s.broadcast {
+			err = subscriber.SubscribeBroadcast(s.topic, s.handler)
+		} else {
+			err = subscriber.Subscribe(s.topic, s.group, s.handler)
+		}
+		if err != nil {
+			return err
+		}
+	}
+	return nil
+}
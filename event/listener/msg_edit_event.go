@@ -0,0 +1,46 @@
+package listener
+
+import (
+	"DiTing-Go/dal/model"
+	"DiTing-Go/global"
+	"DiTing-Go/utils/jsonUtils"
+	wsEnum "DiTing-Go/websocket/domain/enum"
+	wsResp "DiTing-Go/websocket/domain/vo/resp"
+	"DiTing-Go/websocket/service"
+	"context"
+	"github.com/goccy/go-json"
+	"time"
+)
+
+// msgEditEvent 消息编辑事件，刷新会话并通知房间成员
+func msgEditEvent(ctx context.Context, body []byte) error {
+	msg := model.Message{}
+	if err := jsonUtils.UnmarshalMsg(&msg, body); err != nil {
+		return err
+	}
+	uids, err := getRoomUids(ctx, msg.RoomID)
+	if err != nil {
+		return err
+	}
+
+	// 会话预览取自最后一条消息，只需刷新最后一条消息是该消息的会话
+	contact := global.Query.Contact
+	contactQ := contact.WithContext(ctx)
+	if _, err := contactQ.Where(contact.RoomID.Eq(msg.RoomID), contact.LastMsgID.Eq(msg.ID)).Update(contact.UpdateTime, time.Now()); err != nil {
+		global.Logger.Errorf("更新会话失败 %s", err)
+		return err
+	}
+
+	str, _ := json.Marshal(wsResp.MessageEditedResp{
+		Type:     wsEnum.MessageEdited,
+		MsgId:    msg.ID,
+		RoomId:   msg.RoomID,
+		Content:  msg.Content,
+		EditTime: msg.EditTime.UnixMilli(),
+	})
+	// 推送失败只影响在线用户，客户端下次拉取消息时会拿到编辑后的内容
+	for _, uid := range uids {
+		_ = service.Send(uid, str)
+	}
+	return nil
+}
@@ -0,0 +1,34 @@
+package listener
+
+import (
+	"DiTing-Go/domain/dto"
+	"DiTing-Go/utils/jsonUtils"
+	wsEnum "DiTing-Go/websocket/domain/enum"
+	wsResp "DiTing-Go/websocket/domain/vo/resp"
+	"DiTing-Go/websocket/service"
+	"context"
+	"github.com/goccy/go-json"
+)
+
+// msgPinEvent 置顶消息变化事件，通知房间成员刷新置顶列表
+func msgPinEvent(ctx context.Context, body []byte) error {
+	pinDto := dto.MsgPinDto{}
+	if err := jsonUtils.UnmarshalMsg(&pinDto, body); err != nil {
+		return err
+	}
+	uids, err := getRoomUids(ctx, pinDto.RoomId)
+	if err != nil {
+		return err
+	}
+	str, _ := json.Marshal(wsResp.MessagePinnedResp{
+		Type:   wsEnum.MessagePinned,
+		RoomId: pinDto.RoomId,
+		MsgId:  pinDto.MsgId,
+		Uid:    pinDto.Uid,
+		Action: pinDto.Action,
+	})
+	for _, uid := range uids {
+		_ = service.Send(uid, str)
+	}
+	return nil
+}
@@ -0,0 +1,45 @@
+package listener
+
+import (
+	"DiTing-Go/domain/dto"
+	"DiTing-Go/global"
+	"DiTing-Go/utils/jsonUtils"
+	wsEnum "DiTing-Go/websocket/domain/enum"
+	wsResp "DiTing-Go/websocket/domain/vo/resp"
+	"DiTing-Go/websocket/service"
+	"context"
+	"github.com/goccy/go-json"
+)
+
+// msgReactionEvent 表情回应事件，只推送给房间成员，不更新会话
+func msgReactionEvent(ctx context.Context, body []byte) error {
+	reactionDto := dto.MsgReactionDto{}
+	if err := jsonUtils.UnmarshalMsg(&reactionDto, body); err != nil {
+		return err
+	}
+	uids, err := getRoomUids(ctx, reactionDto.RoomId)
+	if err != nil {
+		return err
+	}
+	// 推送时重新统计，保证客户端拿到的是最新数量
+	msgReaction := global.Query.MessageReaction
+	count, err := msgReaction.WithContext(ctx).Where(msgReaction.MsgID.Eq(reactionDto.MsgId), msgReaction.Emoji.Eq(reactionDto.Emoji)).Count()
+	if err != nil {
+		global.Logger.Errorf("统计表情回应失败 %s", err)
+		return err
+	}
+
+	str, _ := json.Marshal(wsResp.MessageReactionResp{
+		Type:   wsEnum.MessageReaction,
+		MsgId:  reactionDto.MsgId,
+		RoomId: reactionDto.RoomId,
+		Uid:    reactionDto.Uid,
+		Emoji:  reactionDto.Emoji,
+		Action: reactionDto.Action,
+		Count:  count,
+	})
+	for _, uid := range uids {
+		_ = service.Send(uid, str)
+	}
+	return nil
+}
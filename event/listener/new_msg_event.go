@@ -12,80 +12,58 @@ import (
 	"context"
 	"encoding/json"
 	"fmt"
-	"github.com/apache/rocketmq-client-go/v2"
-	"github.com/apache/rocketmq-client-go/v2/consumer"
-	"github.com/apache/rocketmq-client-go/v2/primitive"
-	"github.com/spf13/viper"
 	"time"
 )
 
-func init() {
-	host := viper.GetString("rocketmq.host")
-	// 设置推送消费者
-	rocketSendMsgConsumer, _ := rocketmq.NewPushConsumer(
-		//消费组
-		consumer.WithGroupName(enum.NewMessageTopic+"-send-message"),
-		// namesrv地址
-		consumer.WithNameServer([]string{host}),
-	)
-	err := rocketSendMsgConsumer.Subscribe(enum.NewMessageTopic, consumer.MessageSelector{}, UpdateContactEvent)
-	if err != nil {
-		global.Logger.Panicf("subscribe error: %s", err.Error())
+// UpdateContactEvent 新消息事件，更新会话并推送给房间成员
+func UpdateContactEvent(ctx context.Context, body []byte) error {
+	// 解码
+	msg := model.Message{}
+	if err := json.Unmarshal(body, &msg); err != nil {
+		global.Logger.Errorf("jsonUtils unmarshal error: %s", err.Error())
+		return err
 	}
-	err = rocketSendMsgConsumer.Start()
-	if err != nil {
-		global.Logger.Panicf("start consumer error: %s", err.Error())
+	if err := updateContact(msg); err != nil {
+		global.Logger.Errorf("更新会话失败 %s", err)
+		return err
 	}
-
-	//// 设置推送消费者
-	//rocketUpdateContactConsumer, _ := rocketmq.NewPushConsumer(
-	//	//消费组
-	//	consumer.WithGroupName(enum.NewMessageTopic+"-update-contact"),
-	//	// namesrv地址
-	//	consumer.WithNameServer([]string{host}),
-	//)
-	//err := rocketUpdateContactConsumer.Subscribe(enum.NewMessageTopic, consumer.MessageSelector{}, SendMsgEvent)
-	//if err != nil {
-	//	global.Logger.Panicf("subscribe error: %s", err.Error())
-	//}
-	//err = rocketUpdateContactConsumer.Start()
-	//if err != nil {
-	//	global.Logger.Panicf("start consumer error: %s", err.Error())
-	//}
-}
-
-func UpdateContactEvent(ctx context.Context, ext ...*primitive.MessageExt) (consumer.ConsumeResult, error) {
-	for i := range ext {
-		// 解码
-		msg := model.Message{}
-		msgByte := ext[i].Message.Body
-		err := json.Unmarshal(msgByte, &msg)
-		if err != nil {
-			global.Logger.Errorf("jsonUtils unmarshal error: %s", err.Error())
-			return consumer.ConsumeRetryLater, nil
-		}
-		err = updateContact(msg)
-		if err != nil {
-			global.Logger.Errorf("更新会话失败 %s", err)
-			return consumer.ConsumeRetryLater, nil
-		}
-		err = sendMsg(msg)
-		if err != nil {
-			global.Logger.Errorf("发送消息失败 %s", err)
-			return consumer.ConsumeRetryLater, nil
-		}
+	if err := sendMsg(msg); err != nil {
+		global.Logger.Errorf("发送消息失败 %s", err)
+		return err
 	}
-	return consumer.ConsumeSuccess, nil
+	return nil
 }
+
 func updateContact(msg model.Message) error {
-	// 更新会话表
 	ctx := context.Background()
+	uids, err := getRoomUids(ctx, msg.RoomID)
+	if err != nil {
+		return err
+	}
+	//更新会话表
+	update := model.Contact{
+		LastMsgID:  msg.ID,
+		UpdateTime: time.Now(),
+		ActiveTime: time.Now(),
+	}
+	contact := global.Query.Contact
+	contactQ := contact.WithContext(ctx)
+	_, err = contactQ.Where(contact.UID.In(uids...), contact.RoomID.Eq(msg.RoomID)).Updates(&update)
+	if err != nil {
+		global.Logger.Errorf("更新会话失败 %s", err)
+		return err
+	}
+	return nil
+}
+
+// getRoomUids 查询房间中的所有用户，单聊为双方，群聊为全部群成员
+func getRoomUids(ctx context.Context, roomID int64) ([]int64, error) {
 	room := global.Query.Room
 	roomQ := room.WithContext(ctx)
-	roomR, err := roomQ.Where(room.ID.Eq(msg.RoomID)).First()
+	roomR, err := roomQ.Where(room.ID.Eq(roomID)).First()
 	if err != nil {
 		global.Logger.Errorf("查询房间失败 %s", err)
-		return err
+		return nil, err
 	}
 	var uids []int64
 	if roomR.Type == enum.PERSONAL {
@@ -94,7 +72,7 @@ func updateContact(msg model.Message) error {
 		roomFriendR, err := roomFriendQ.Where(roomFriend.RoomID.Eq(roomR.ID)).First()
 		if err != nil {
 			global.Logger.Errorf("查询好友房间失败 %s", err)
-			return err
+			return nil, err
 		}
 		uids = []int64{roomFriendR.Uid1, roomFriendR.Uid2}
 	} else if roomR.Type == enum.GROUP {
@@ -104,7 +82,7 @@ func updateContact(msg model.Message) error {
 		roomGroupR, err := roomGroupQ.Where(roomGroup.RoomID.Eq(roomR.ID)).First()
 		if err != nil {
 			global.Logger.Errorf("查询群聊失败 %s", err)
-			return err
+			return nil, err
 		}
 		groupMember := global.Query.GroupMember
 		groupMemberQ := groupMember.WithContext(ctx)
@@ -113,40 +91,22 @@ func updateContact(msg model.Message) error {
 			uids = append(uids, groupMember.UID)
 		}
 	}
-	//更新会话表
-	update := model.Contact{
-		LastMsgID:  msg.ID,
-		UpdateTime: time.Now(),
-		ActiveTime: time.Now(),
-	}
-	contact := global.Query.Contact
-	contactQ := contact.WithContext(ctx)
-	_, err = contactQ.Where(contact.UID.In(uids...), contact.RoomID.Eq(msg.RoomID)).Updates(&update)
-	if err != nil {
-		global.Logger.Errorf("更新会话失败 %s", err)
-		return err
-	}
-	return nil
+	return uids, nil
 }
 
 // SendMsgEvent 新消息事件
-func SendMsgEvent(ctx context.Context, ext ...*primitive.MessageExt) (consumer.ConsumeResult, error) {
-	for i := range ext {
-		// 解码
-		msg := model.Message{}
-		msgByte := ext[i].Message.Body
-		err := json.Unmarshal(msgByte, &msg)
-		if err != nil {
-			global.Logger.Errorf("jsonUtils unmarshal error: %s", err.Error())
-			return consumer.ConsumeRetryLater, nil
-		}
-		err = sendMsg(msg)
-		if err != nil {
-			global.Logger.Errorf("发送消息失败 %s", err)
-			return consumer.ConsumeRetryLater, nil
-		}
+func SendMsgEvent(ctx context.Context, body []byte) error {
+	// 解码
+	msg := model.Message{}
+	if err := json.Unmarshal(body, &msg); err != nil {
+		global.Logger.Errorf("jsonUtils unmarshal error: %s", err.Error())
+		return err
 	}
-	return consumer.ConsumeSuccess, nil
+	if err := sendMsg(msg); err != nil {
+		global.Logger.Errorf("发送消息失败 %s", err)
+		return err
+	}
+	return nil
 }
 
 // sendMsg 发送消息
@@ -164,7 +124,10 @@ func sendMsg(msg model.Message) error {
 		return err
 	}
 	msgBody := resp2.NewMessageResp{
-		Type: wsEnum.NewMessage,
+		Type:        wsEnum.NewMessage,
+		MsgId:       msg.ID,
+		RoomId:      msg.RoomID,
+		ClientMsgId: msg.ClientMsgID,
 	}
 	str, _ := json.Marshal(msgBody)
 	// 单聊
@@ -0,0 +1,63 @@
+package listener
+
+import (
+	"DiTing-Go/domain/dto"
+	"DiTing-Go/global"
+	"DiTing-Go/utils/jsonUtils"
+	wsEnum "DiTing-Go/websocket/domain/enum"
+	wsResp "DiTing-Go/websocket/domain/vo/resp"
+	"DiTing-Go/websocket/service"
+	"context"
+	"github.com/goccy/go-json"
+)
+
+// pollVoteEvent 投票事件，推送最新的投票结果给房间成员，不更新会话
+func pollVoteEvent(ctx context.Context, body []byte) error {
+	voteDto := dto.PollVoteDto{}
+	if err := jsonUtils.UnmarshalMsg(&voteDto, body); err != nil {
+		return err
+	}
+	uids, err := getRoomUids(ctx, voteDto.RoomId)
+	if err != nil {
+		return err
+	}
+	message := global.Query.Message
+	msg, err := message.WithContext(ctx).Where(message.ID.Eq(voteDto.MsgId)).First()
+	if err != nil {
+		global.Logger.Errorf("查询消息失败 %s", err)
+		return err
+	}
+	poll := dto.PollDto{}
+	if err := json.Unmarshal([]byte(msg.Extra), &poll); err != nil {
+		global.Logger.Errorf("json反序列化失败 %s", err)
+		return err
+	}
+	// 推送时重新统计，保证客户端拿到的是最新结果
+	pollVote := global.Query.PollVote
+	votes, err := pollVote.WithContext(ctx).Where(pollVote.MsgID.Eq(voteDto.MsgId)).Find()
+	if err != nil {
+		global.Logger.Errorf("查询投票记录失败 %s", err)
+		return err
+	}
+	counts := make([]int64, len(poll.Options))
+	voters := make(map[int64]bool)
+	for _, vote := range votes {
+		if int(vote.OptionIdx) >= len(counts) {
+			continue
+		}
+		counts[vote.OptionIdx]++
+		voters[vote.UID] = true
+	}
+
+	str, _ := json.Marshal(wsResp.PollUpdatedResp{
+		Type:       wsEnum.PollUpdated,
+		MsgId:      voteDto.MsgId,
+		RoomId:     voteDto.RoomId,
+		VoterCount: int64(len(voters)),
+		Counts:     counts,
+	})
+	for _, uid := range uids {
+		_ = service.Send(uid, str)
+	}
+	return nil
+}
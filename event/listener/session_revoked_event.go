@@ -0,0 +1,18 @@
+package listener
+
+import (
+	"DiTing-Go/domain/dto"
+	"DiTing-Go/utils/jsonUtils"
+	"DiTing-Go/websocket/service"
+	"context"
+)
+
+// sessionRevokedEvent 会话吊销事件，断开本实例上该会话的websocket连接
+func sessionRevokedEvent(ctx context.Context, body []byte) error {
+	revokeSessionDto := dto.RevokeSessionDto{}
+	if err := jsonUtils.UnmarshalMsg(&revokeSessionDto, body); err != nil {
+		return err
+	}
+	service.DisconnectSession(revokeSessionDto.Uid, revokeSessionDto.Sid)
+	return nil
+}
@@ -0,0 +1,40 @@
+package listener
+
+import (
+	"DiTing-Go/domain/dto"
+	"DiTing-Go/global"
+	pkgEnum "DiTing-Go/pkg/domain/enum"
+	"DiTing-Go/utils/jsonUtils"
+	wsEnum "DiTing-Go/websocket/domain/enum"
+	wsResp "DiTing-Go/websocket/domain/vo/resp"
+	"DiTing-Go/websocket/service"
+	"context"
+	"github.com/goccy/go-json"
+)
+
+// userProfileEvent 用户资料变更事件，通知好友和本人其他设备刷新
+func userProfileEvent(ctx context.Context, body []byte) error {
+	profileDto := dto.UserProfileChangedDto{}
+	if err := jsonUtils.UnmarshalMsg(&profileDto, body); err != nil {
+		return err
+	}
+
+	userFriend := global.Query.UserFriend
+	userFriendQ := userFriend.WithContext(ctx)
+	friends, err := userFriendQ.Where(userFriend.UID.Eq(profileDto.Uid), userFriend.DeleteStatus.Eq(pkgEnum.NORMAL)).Find()
+	if err != nil {
+		global.Logger.Errorf("查询好友列表失败 %s", err)
+		return err
+	}
+
+	str, _ := json.Marshal(wsResp.UserProfileChangedResp{
+		Type: wsEnum.UserProfileChanged,
+		Uid:  profileDto.Uid,
+	})
+	// 推送失败只影响在线提醒，客户端下次拉取时仍会刷新
+	_ = service.Send(profileDto.Uid, str)
+	for _, friend := range friends {
+		_ = service.Send(friend.FriendUID, str)
+	}
+	return nil
+}
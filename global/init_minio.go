@@ -20,6 +20,6 @@ func init() {
 	})
 	MinioClient = minioClient
 	if err != nil {
-		Logger.Fatal("minio client create fail, err %+v", err)
+		Logger.Fatalf("minio client create fail, err %+v", err)
 	}
 }
@@ -1,30 +0,0 @@
-package global
-
-import (
-	"github.com/apache/rocketmq-client-go/v2"
-	"github.com/apache/rocketmq-client-go/v2/producer"
-	"github.com/spf13/viper"
-)
-
-var RocketProducer rocketmq.Producer
-
-func init() {
-	host := viper.GetString("rocketmq.host")
-	group := viper.GetString("rocketmq.group")
-	// 手动指定 broker IP 地址
-	//brokerAddr := "127.0.0.1:10911"
-	RocketProducer, _ = rocketmq.NewProducer(
-		// 设置  nameSrvAddr
-		// nameSrvAddr 是 Topic 路由注册中心
-		producer.WithNameServer([]string{host}),
-		// 指定发送失败时的重试时间
-		producer.WithRetry(3),
-		// 设置 Group
-		producer.WithGroupName(group),
-	)
-	// 开始连接
-	err := RocketProducer.Start()
-	if err != nil {
-		Logger.Panicf(": %s", err.Error())
-	}
-}
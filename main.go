@@ -1,8 +1,12 @@
 package main
 
 import (
-	_ "DiTing-Go/event/listener"
+	"DiTing-Go/event/listener"
+	"DiTing-Go/global"
 	"DiTing-Go/routes"
+	"DiTing-Go/service"
+	"DiTing-Go/utils/mq"
+	"DiTing-Go/utils/outbox"
 )
 
 // swagger 中添加header.Authorization:token 校验 token
@@ -12,6 +16,25 @@ import (
 func main() {
 	//global.InitDB()
 	//dal.DB.AutoMigrate(&model.User{}) // sql文件中没有user表，这里手动导入一下
+	// 注册事件监听并开始消费
+	if err := listener.Register(mq.Default); err != nil {
+		global.Logger.Panicf("register listener error: %s", err.Error())
+	}
+	if err := mq.Default.Start(); err != nil {
+		global.Logger.Panicf("start consumer error: %s", err.Error())
+	}
+	// 投递发件箱中的事件
+	go outbox.Relay()
+	// 过期超时未处理的好友申请
+	go service.ExpireApplyJob()
+	// 补全历史用户的昵称拼音
+	go service.BackfillNamePinyinJob()
+	// 投递到期的定时消息
+	go service.ScheduledMsgJob()
+	// 删除到达自毁时间的消息
+	go service.ExpireMsgJob()
+	// 删除不再被引用的文件
+	go service.ObjectGcJob()
 	routes.InitRouter()
 
 }
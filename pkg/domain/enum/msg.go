@@ -4,10 +4,13 @@ var MsgFlags = map[int]string{
 	SUCCESS:                        "ok",
 	ERROR:                          "fail",
 	INVALID_PARAMS:                 "请求参数错误",
+	TOO_MANY_REQUESTS:              "请求过于频繁，请稍后再试",
 	ERROR_AUTH_CHECK_TOKEN_FAIL:    "Token鉴权失败",
 	ERROR_AUTH_CHECK_TOKEN_TIMEOUT: "Token已超时",
 	ERROR_AUTH_TOKEN:               "Token生成失败",
 	ERROR_AUTH:                     "Token错误",
+	ERROR_LOGIN_LOCKED:             "登录失败次数过多，请稍后再试",
+	ERROR_USER_BLOCKED:             "你们之间存在拉黑关系，无法发送消息",
 }
 
 func GetMsg(code int) string {
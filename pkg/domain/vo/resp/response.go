@@ -68,6 +68,26 @@ func ErrorResponseData(msg string) ResponseData {
 	}
 }
 
+// ErrorResponseDataWithCode 是一个辅助函数，用于创建带业务错误码的错误响应
+func ErrorResponseDataWithCode(code int, data interface{}) ResponseData {
+	return ResponseData{
+		Code:    code,
+		Success: false,
+		Message: enum.GetMsg(code),
+		Data:    data,
+	}
+}
+
+// RetryAfterData 锁定等需要稍后重试的响应数据
+type RetryAfterData struct {
+	RetryAfter int64 `json:"retryAfter"` // 多少秒后可以重试
+}
+
+// TooManyRequestsResponseData 是一个辅助函数，用于创建请求过于频繁的响应
+func TooManyRequestsResponseData(retryAfter int64) ResponseData {
+	return ErrorResponseDataWithCode(enum.TOO_MANY_REQUESTS, RetryAfterData{RetryAfter: retryAfter})
+}
+
 // SuccessResponseData 是一个辅助函数，用于创建成功响应
 func SuccessResponseData(data interface{}) ResponseData {
 	return ResponseData{
@@ -27,7 +27,8 @@ func JWT() gin.HandlerFunc {
 			return
 		}
 		// parts[1]是获取到的tokenString，我们使用之前定义好的解析JWT的函数来解析它
-		token, err := utils.ParseToken(parts[1])
+		// 同时校验所属会话是否已被吊销
+		token, err := utils.CheckToken(parts[1])
 		if err != nil {
 			resp.ErrorResponse(c, "3无权限访问")
 			c.Abort()
@@ -35,6 +36,7 @@ func JWT() gin.HandlerFunc {
 		}
 		//把解析出来的token存储到请求的上下文c上,方便后续的处理函数获取
 		c.Set("uid", token.Uid)
+		c.Set("sid", token.Sid)
 		c.Next()
 	}
 }
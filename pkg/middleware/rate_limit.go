@@ -0,0 +1,29 @@
+package middleware
+
+import (
+	"DiTing-Go/pkg/domain/vo/resp"
+	"DiTing-Go/pkg/utils"
+	"github.com/gin-gonic/gin"
+	"math"
+	"net/http"
+	"strconv"
+)
+
+// RateLimit 限流中间件，登录后按uid限流，未登录按ip限流
+func RateLimit(name string, rate float64, burst int64) gin.HandlerFunc {
+	rule := utils.NewRateLimitRule(name, rate, burst)
+	return func(c *gin.Context) {
+		subject := "ip:" + c.ClientIP()
+		if uid := c.GetInt64("uid"); uid != 0 {
+			subject = "uid:" + strconv.FormatInt(uid, 10)
+		}
+		allowed, wait := rule.Allow(subject)
+		if !allowed {
+			retryAfter := int64(math.Ceil(wait.Seconds()))
+			c.Header("Retry-After", strconv.FormatInt(retryAfter, 10))
+			c.AbortWithStatusJSON(http.StatusTooManyRequests, resp.TooManyRequestsResponseData(retryAfter))
+			return
+		}
+		c.Next()
+	}
+}
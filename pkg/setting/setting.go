@@ -12,6 +12,9 @@ func init() {
 	viper.SetConfigType("yaml")
 	// 添加配置文件的路径，指定 config 目录下寻找
 	viper.AddConfigPath("./conf")
+	// 在子包目录中运行测试时向上查找
+	viper.AddConfigPath("../conf")
+	viper.AddConfigPath("../../conf")
 	err := viper.ReadInConfig()
 	if err != nil {
 		log.Fatalf("Fail to parse 'conf/config.yml': %v", err)
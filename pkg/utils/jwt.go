@@ -1,7 +1,13 @@
 package utils
 
 import (
+	domainEnum "DiTing-Go/domain/enum"
 	"DiTing-Go/global"
+	"crypto/rand"
+	"crypto/sha256"
+	"encoding/base64"
+	"encoding/hex"
+	"fmt"
 	"github.com/dgrijalva/jwt-go"
 	"github.com/pkg/errors"
 	"github.com/spf13/viper"
@@ -13,21 +19,62 @@ var jwtSecret = []byte(viper.GetString("jwt.secret"))
 
 // Claims结构体用于JWT的载荷部分
 type Claims struct {
-	Uid                int64 `json:"uid"` // 用户ID
-	jwt.StandardClaims       // 标准声明
+	Uid                int64  `json:"uid"` // 用户ID
+	Sid                string `json:"sid"` // 会话ID，吊销会话后该会话签发的所有token失效
+	jwt.StandardClaims        // 标准声明
 }
 
-// GenerateToken 生成token
+// GetAccessTokenExpire 访问令牌有效期
+func GetAccessTokenExpire() time.Duration {
+	if expire := viper.GetDuration("jwt.accessExpire"); expire > 0 {
+		return expire
+	}
+	return domainEnum.DefaultAccessTokenExpire
+}
+
+// GetRefreshTokenExpire 刷新令牌有效期，也是会话的有效期
+func GetRefreshTokenExpire() time.Duration {
+	if expire := viper.GetDuration("jwt.refreshExpire"); expire > 0 {
+		return expire
+	}
+	return domainEnum.DefaultRefreshTokenExpire
+}
+
+// RandomToken 生成n字节的随机串，使用url安全的base64编码
+func RandomToken(n int) (string, error) {
+	b := make([]byte, n)
+	if _, err := rand.Read(b); err != nil {
+		return "", err
+	}
+	return base64.RawURLEncoding.EncodeToString(b), nil
+}
+
+// HashToken 计算令牌摘要，服务端只保存摘要
+func HashToken(token string) string {
+	sum := sha256.Sum256([]byte(token))
+	return hex.EncodeToString(sum[:])
+}
+
+// GenerateToken 生成访问令牌
 // uid 用户ID
+// sid 会话ID
 // 返回生成的token字符串和错误信息
-func GenerateToken(uid int64) (string, error) {
-	nowTime := time.Now()                           // 当前时间
-	expireTime := nowTime.Add(24 * 360 * time.Hour) // 过期时间为360天后
+func GenerateToken(uid int64, sid string) (string, error) {
+	nowTime := time.Now()                             // 当前时间
+	expireTime := nowTime.Add(GetAccessTokenExpire()) // 访问令牌只短期有效，过期后使用刷新令牌换取
 
+	jti, err := RandomToken(16)
+	if err != nil {
+		global.Logger.Errorf("generate token failed: %v", err)
+		return "", err
+	}
 	claims := Claims{
 		uid,
+		sid,
 		jwt.StandardClaims{
+			Id:        jti,               // 令牌ID
 			ExpiresAt: expireTime.Unix(), // 过期时间
+			IssuedAt:  nowTime.Unix(),    // 签发时间
 			Issuer:    "diting-go",       // 签发者
 		},
 	}
@@ -47,6 +94,9 @@ func GenerateToken(uid int64) (string, error) {
 func ParseToken(tokenString string) (*Claims, error) {
 	// 解析token并验证签名
 	token, err := jwt.ParseWithClaims(tokenString, &Claims{}, func(token *jwt.Token) (i interface{}, err error) {
+		if _, ok := token.Method.(*jwt.SigningMethodHMAC); !ok {
+			return nil, errors.New("unexpected signing method")
+		}
 		return jwtSecret, nil // 返回密钥进行签名验证
 	})
 	if err != nil {
@@ -58,3 +108,23 @@ func ParseToken(tokenString string) (*Claims, error) {
 	}
 	return nil, errors.New("invalid token") // 返回无效token的错误
 }
+
+// CheckToken 解析token并检查所属会话是否已被吊销
+func CheckToken(tokenString string) (*Claims, error) {
+	claims, err := ParseToken(tokenString)
+	if err != nil {
+		return nil, err
+	}
+	if claims.Sid == "" || claims.Id == "" {
+		return nil, errors.New("invalid token")
+	}
+	n, err := global.Rdb.Exists(fmt.Sprintf(domainEnum.TokenSession, claims.Sid)).Result()
+	if err != nil {
+		global.Logger.Errorf("查询会话失败: %v", err)
+		return nil, err
+	}
+	if n == 0 {
+		return nil, errors.New("session revoked")
+	}
+	return claims, nil
+}
@@ -0,0 +1,28 @@
+package utils
+
+import (
+	"crypto/subtle"
+	"golang.org/x/crypto/bcrypt"
+)
+
+// HashPassword 使用 bcrypt 生成密码哈希
+func HashPassword(password string) (string, error) {
+	hash, err := bcrypt.GenerateFromPassword([]byte(password), bcrypt.DefaultCost)
+	if err != nil {
+		return "", err
+	}
+	return string(hash), nil
+}
+
+// CheckPassword 校验密码，needRehash 表示库中仍是明文或强度不足的旧哈希，需要在登录成功后重新哈希
+func CheckPassword(hashed string, password string) (ok bool, needRehash bool) {
+	cost, err := bcrypt.Cost([]byte(hashed))
+	if err != nil {
+		// 历史账号的明文密码
+		return subtle.ConstantTimeCompare([]byte(hashed), []byte(password)) == 1, true
+	}
+	if err := bcrypt.CompareHashAndPassword([]byte(hashed), []byte(password)); err != nil {
+		return false, false
+	}
+	return true, cost < bcrypt.DefaultCost
+}
@@ -0,0 +1,41 @@
+//go:build integration
+
+package utils
+
+import (
+	"golang.org/x/crypto/bcrypt"
+	"testing"
+)
+
+func TestCheckPassword(t *testing.T) {
+	hashed, err := HashPassword("secret")
+	if err != nil {
+		t.Fatalf("HashPassword: %v", err)
+	}
+	weakHashed, err := bcrypt.GenerateFromPassword([]byte("secret"), bcrypt.MinCost)
+	if err != nil {
+		t.Fatalf("GenerateFromPassword: %v", err)
+	}
+	tests := []struct {
+		name           string
+		hashed         string
+		password       string
+		wantOk         bool
+		wantNeedRehash bool
+	}{
+		{name: "哈希密码正确", hashed: hashed, password: "secret", wantOk: true, wantNeedRehash: false},
+		{name: "哈希密码错误", hashed: hashed, password: "wrong", wantOk: false, wantNeedRehash: false},
+		{name: "低强度哈希正确", hashed: string(weakHashed), password: "secret", wantOk: true, wantNeedRehash: true},
+		{name: "明文密码正确", hashed: "secret", password: "secret", wantOk: true, wantNeedRehash: true},
+		{name: "明文密码错误", hashed: "secret", password: "wrong", wantOk: false, wantNeedRehash: true},
+		{name: "密码为空", hashed: hashed, password: "", wantOk: false, wantNeedRehash: false},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ok, needRehash := CheckPassword(tt.hashed, tt.password)
+			if ok != tt.wantOk || needRehash != tt.wantNeedRehash {
+				t.Errorf("CheckPassword() = (%v, %v), want (%v, %v)", ok, needRehash, tt.wantOk, tt.wantNeedRehash)
+			}
+		})
+	}
+}
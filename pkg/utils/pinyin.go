@@ -0,0 +1,32 @@
+package utils
+
+import (
+	"github.com/mozillazg/go-pinyin"
+	"strings"
+	"unicode"
+)
+
+// NamePinyin 生成昵称的拼音搜索串，全拼和首字母用空格分隔，非汉字原样保留，空白字符忽略
+// 例如 "张三abc" 生成 "zhangsanabc zsabc"
+func NamePinyin(name string) string {
+	args := pinyin.NewArgs()
+	args.Fallback = func(r rune, a pinyin.Args) []string {
+		if unicode.IsSpace(r) {
+			return []string{}
+		}
+		return []string{string(r)}
+	}
+	parts := pinyin.LazyPinyin(strings.ToLower(name), args)
+	var full, initials strings.Builder
+	for _, part := range parts {
+		if part == "" {
+			continue
+		}
+		full.WriteString(part)
+		initials.WriteString(string([]rune(part)[0]))
+	}
+	if full.String() == initials.String() {
+		return full.String()
+	}
+	return full.String() + " " + initials.String()
+}
@@ -0,0 +1,23 @@
+//go:build integration
+
+package utils
+
+import "testing"
+
+func TestNamePinyin(t *testing.T) {
+	tests := []struct {
+		name string
+		want string
+	}{
+		{name: "张三abc", want: "zhangsanabc zsabc"},
+		{name: "张 三", want: "zhangsan zs"},
+		{name: "Tom", want: "tom"},
+		{name: "abc 123", want: "abc123"},
+		{name: "", want: ""},
+	}
+	for _, tt := range tests {
+		if got := NamePinyin(tt.name); got != tt.want {
+			t.Errorf("NamePinyin(%q) = %q, want %q", tt.name, got, tt.want)
+		}
+	}
+}
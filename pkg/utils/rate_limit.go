@@ -0,0 +1,71 @@
+package utils
+
+import (
+	domainEnum "DiTing-Go/domain/enum"
+	"DiTing-Go/global"
+	"fmt"
+	"github.com/go-redis/redis"
+	"github.com/spf13/viper"
+	"time"
+)
+
+// 令牌桶脚本，按时间差补充令牌后尝试取一个，返回 {是否允许, 需要等待的毫秒数}
+var tokenBucketScript = redis.NewScript(`
+local rate = tonumber(ARGV[1])
+local burst = tonumber(ARGV[2])
+local now = tonumber(ARGV[3])
+local bucket = redis.call('HMGET', KEYS[1], 'tokens', 'ts')
+local tokens = tonumber(bucket[1]) or burst
+local ts = tonumber(bucket[2]) or now
+tokens = math.min(burst, tokens + math.max(0, now - ts) * rate / 1000)
+local allowed = 0
+local wait = 0
+if tokens >= 1 then
+	tokens = tokens - 1
+	allowed = 1
+else
+	wait = math.ceil((1 - tokens) * 1000 / rate)
+end
+redis.call('HMSET', KEYS[1], 'tokens', tokens, 'ts', now)
+redis.call('PEXPIRE', KEYS[1], math.ceil(burst * 1000 / rate) + 1000)
+return {allowed, wait}
+`)
+
+// RateLimitRule 令牌桶规则
+type RateLimitRule struct {
+	// 限流规则名
+	Name string
+	// 每秒补充的令牌数
+	Rate float64
+	// 桶容量，即允许的突发请求数
+	Burst int64
+}
+
+// NewRateLimitRule 创建限流规则，配置项 rateLimit.<name>.rate、rateLimit.<name>.burst 可覆盖默认值
+func NewRateLimitRule(name string, rate float64, burst int64) RateLimitRule {
+	if r := viper.GetFloat64(fmt.Sprintf("rateLimit.%s.rate", name)); r > 0 {
+		rate = r
+	}
+	if b := viper.GetInt64(fmt.Sprintf("rateLimit.%s.burst", name)); b > 0 {
+		burst = b
+	}
+	return RateLimitRule{Name: name, Rate: rate, Burst: burst}
+}
+
+// Allow 消耗subject的一个令牌，被限流时返回需要等待的时间，redis异常时放行
+func (rule RateLimitRule) Allow(subject string) (bool, time.Duration) {
+	key := fmt.Sprintf(domainEnum.RateLimitBucket, rule.Name, subject)
+	result, err := tokenBucketScript.Run(global.Rdb, []string{key}, rule.Rate, rule.Burst, time.Now().UnixMilli()).Result()
+	if err != nil {
+		global.Logger.Errorf("限流检查失败 %s", err)
+		return true, 0
+	}
+	values, ok := result.([]interface{})
+	if !ok || len(values) != 2 {
+		global.Logger.Errorf("限流脚本返回值异常 %v", result)
+		return true, 0
+	}
+	allowed, _ := values[0].(int64)
+	wait, _ := values[1].(int64)
+	return allowed == 1, time.Duration(wait) * time.Millisecond
+}
@@ -40,23 +40,34 @@ func initGin() {
 	// 添加swagger访问路由
 	router.GET("/swagger/*any", ginSwagger.WrapHandler(swaggerFiles.Handler))
 	// 不需要身份验证的路由
+	// 访问头像，列表页会批量加载，不走登录注册的限流
+	router.GET("/api/public/avatar", controller.AvatarController)
 	apiPublic := router.Group("/api/public")
+	apiPublic.Use(middleware.RateLimit("public", 2, 20))
 	{
 		//注册
 		apiPublic.POST("/register", controller.RegisterController)
 		//登录
 		apiPublic.POST("/login", controller.LoginController)
+		//刷新令牌
+		apiPublic.POST("/refresh", controller.RefreshTokenController)
+		//创建扫码登录二维码
+		apiPublic.POST("/qrlogin", controller.CreateQrLoginController)
+		//等待扫码登录结果
+		apiPublic.GET("/qrlogin", controller.PollQrLoginController)
 	}
 
 	apiUser := router.Group("/api/user")
-	apiUser.Use(middleware.JWT())
+	apiUser.Use(middleware.JWT(), middleware.RateLimit("user", 10, 30))
 	{
 		//添加好友
-		apiUser.POST("/add", controller.ApplyFriendController)
+		apiUser.POST("/add", middleware.RateLimit("friendApply", 0.2, 5), controller.ApplyFriendController)
 		//删除好友
 		apiUser.DELETE("/delete/", controller.DeleteFriendController)
 		//同意好友申请
 		apiUser.PUT("/agree", controller.AgreeFriendController)
+		//拒绝好友申请
+		apiUser.PUT("/reject", controller.RejectFriendController)
 		//获取好友申请列表
 		apiUser.GET("/getApplyList", controller.GetUserApplyController)
 		//获取好友列表
@@ -67,11 +78,35 @@ func initGin() {
 		apiUser.GET("/unreadApplyNum", controller.UnreadApplyNumController)
 		//根据好友昵称搜索好友
 		apiUser.GET("/getUserInfoByName", controller.GetUserInfoByNameController)
+		//退出登录
+		apiUser.POST("/logout", controller.LogoutController)
+		//获取登录设备列表
+		apiUser.GET("/devices", controller.GetDeviceListController)
+		//踢下登录设备
+		apiUser.DELETE("/devices/:id", controller.KickDeviceController)
+		//确认扫码登录
+		apiUser.POST("/qrlogin/confirm", controller.ConfirmQrLoginController)
+		//修改好友备注和标签
+		apiUser.PUT("/friend/remark", controller.UpdateFriendRemarkController)
+		//获取好友标签列表
+		apiUser.GET("/friend/tags", controller.GetFriendTagsController)
+		//好友推荐
+		apiUser.GET("/recommendations", controller.GetRecommendController)
+		//拉黑用户
+		apiUser.POST("/block", controller.BlockUserController)
+		//移出黑名单
+		apiUser.DELETE("/block/:uid", controller.UnblockUserController)
+		//获取黑名单列表
+		apiUser.GET("/blockList", controller.GetBlockListController)
+		//修改个人资料
+		apiUser.PUT("/profile", controller.UpdateProfileController)
+		//签发头像上传地址
+		apiUser.GET("/avatar/preSigned", middleware.RateLimit("avatar", 0.2, 5), controller.GetAvatarPreSignedController)
 		// TODO:测试使用
 		apiUser.GET("/test", test)
 	}
 	apiGroup := router.Group("/api/group")
-	apiGroup.Use(middleware.JWT())
+	apiGroup.Use(middleware.JWT(), middleware.RateLimit("group", 5, 20))
 	{
 		// 创建群聊
 		apiGroup.POST("/create", controller.CreateGroupController)
@@ -90,7 +125,7 @@ func initGin() {
 	}
 
 	apiContact := router.Group("/api/contact")
-	apiContact.Use(middleware.JWT())
+	apiContact.Use(middleware.JWT(), middleware.RateLimit("contact", 10, 30))
 	{
 		// 获取联系人列表
 		apiContact.GET("getContactList", controller.GetContactListController)
@@ -105,17 +140,58 @@ func initGin() {
 	}
 
 	apiMsg := router.Group("/api/chat")
-	apiMsg.Use(middleware.JWT())
+	apiMsg.Use(middleware.JWT(), middleware.RateLimit("chat", 5, 20))
 	{
 		// 发送消息
 		apiMsg.POST("msg", controller.SendMessageController)
+		// 编辑消息
+		apiMsg.PUT("msg", controller.EditMessageController)
+		// 添加或取消表情回应
+		apiMsg.POST("reaction", controller.ReactionController)
+		// 转发消息
+		apiMsg.POST("forward", controller.ForwardMessageController)
+		// 置顶消息
+		apiMsg.POST("pin", controller.PinMessageController)
+		// 取消置顶消息
+		apiMsg.DELETE("pin/:msgId", controller.UnpinMessageController)
+		// 获取置顶消息列表
+		apiMsg.GET("pinned", controller.GetPinnedMessageController)
+		// 收藏消息
+		apiMsg.POST("favorite", controller.AddFavoriteController)
+		// 修改收藏的标签和备注
+		apiMsg.PUT("favorite", controller.UpdateFavoriteController)
+		// 取消收藏
+		apiMsg.DELETE("favorite/:id", controller.DeleteFavoriteController)
+		// 获取收藏列表
+		apiMsg.GET("favorites", controller.GetFavoriteListController)
+		// 获取待发送的定时消息
+		apiMsg.GET("scheduled", controller.GetScheduledMsgListController)
+		// 取消定时消息
+		apiMsg.DELETE("scheduled/:id", controller.CancelScheduledMsgController)
+		// 设置房间消息自毁时长
+		apiMsg.PUT("room/ttl", controller.UpdateRoomTtlController)
+		// 发起投票
+		apiMsg.POST("poll", controller.CreatePollController)
+		// 投票
+		apiMsg.POST("poll/vote", controller.VotePollController)
+		// 获取投票结果
+		apiMsg.GET("poll", controller.GetPollController)
 	}
 
 	apiFile := router.Group("/api/file")
 	apiFile.Use(middleware.JWT())
 	{
+		// 上传和下载分开限流，浏览聊天记录加载图片不占用上传额度
+		uploadLimit := middleware.RateLimit("file", 1, 10)
+		downloadLimit := middleware.RateLimit("download", 20, 100)
 		// 上传文件
-		apiFile.GET("getPreSigned", service.GetPreSigned)
+		apiFile.GET("getPreSigned", uploadLimit, service.GetPreSigned)
+		// 下载文件
+		apiFile.GET("download", downloadLimit, service.DownloadService)
+		// 上传完成
+		apiFile.POST("complete", uploadLimit, service.CompleteUploadService)
+		// 下载收藏中的文件
+		apiFile.GET("favorite", downloadLimit, controller.FavoriteDownloadController)
 	}
 
 	err := router.Run(":5000")
@@ -13,9 +13,10 @@ type RoomDto struct {
 	Avatar string
 	Name   string
 	Type   int
+	MsgTtl int32
 }
 
-func BuildContactDaoList(contactList []model.Contact, userList []*model.User, messageList []*model.Message, roomList []*model.Room, roomFriendList []*model.RoomFriend, roomGroupList []*model.RoomGroup, countMap cmap.ConcurrentMap[string, int64]) []dto.ContactDto {
+func BuildContactDaoList(contactList []model.Contact, userList []*model.User, messageList []*model.Message, roomList []*model.Room, roomFriendList []*model.RoomFriend, roomGroupList []*model.RoomGroup, countMap cmap.ConcurrentMap[string, int64], remarkMap map[int64]string) []dto.ContactDto {
 	contactDtoList := make([]dto.ContactDto, 0)
 
 	userMap := make(map[int64]*model.User)
@@ -41,6 +42,7 @@ func BuildContactDaoList(contactList []model.Contact, userList []*model.User, me
 	for _, room := range roomList {
 		roomDto := RoomDto{}
 		roomDto.ID = room.ID
+		roomDto.MsgTtl = room.MsgTTL
 		if room.Type == enum.PERSONAL {
 			userId := roomFriendMap[room.ID].Uid1
 			if userId == contactList[0].UID {
@@ -49,6 +51,9 @@ func BuildContactDaoList(contactList []model.Contact, userList []*model.User, me
 			user := userMap[userId]
 			roomDto.Avatar = user.Avatar
 			roomDto.Name = user.Name
+			if remark, ok := remarkMap[userId]; ok {
+				roomDto.Name = remark
+			}
 			roomDto.Type = enum.PERSONAL
 		} else {
 			roomGroup := roomGroupMap[room.ID]
@@ -72,6 +77,7 @@ func BuildContactDaoList(contactList []model.Contact, userList []*model.User, me
 		unreadCount, _ := countMap.Get(strconv.FormatInt(contact.RoomID, 10))
 		contactDto.UnreadCount = int32(unreadCount)
 		contactDto.Type = roomMap[contact.RoomID].Type
+		contactDto.MsgTtl = roomMap[contact.RoomID].MsgTtl
 		contactDtoList = append(contactDtoList, contactDto)
 	}
 	return contactDtoList
@@ -2,10 +2,14 @@ package adapter
 
 import (
 	"DiTing-Go/dal/model"
+	"DiTing-Go/domain/enum"
 	"DiTing-Go/domain/vo/resp"
+	pkgEnum "DiTing-Go/pkg/domain/enum"
+	"encoding/json"
+	"slices"
 )
 
-func BuildMessageRespByMsgAndUser(msgList *[]model.Message, userMap map[int64]*model.User) []resp.MessageResp {
+func BuildMessageRespByMsgAndUser(msgList *[]model.Message, userMap map[int64]*model.User, blockedUids []int64, reactionMap map[int64][]resp.ReactionResp) []resp.MessageResp {
 	var messageRespList []resp.MessageResp
 	for i := range len(*msgList) {
 		messageResp := resp.MessageResp{}
@@ -22,9 +26,23 @@ func BuildMessageRespByMsgAndUser(msgList *[]model.Message, userMap map[int64]*m
 		message.Type = msg.Type
 		message.Body.Content = msg.Content
 		message.Body.Reply = msg.ReplyMsgID
+		if slices.Contains(enum.ExtraMessageTypes, msg.Type) {
+			message.Body.Extra = json.RawMessage(msg.Extra)
+		}
+		message.ClientMsgId = msg.ClientMsgID
+		message.Forwarded = msg.Forwarded == pkgEnum.YES
+		if !msg.EditTime.IsZero() {
+			message.Edited = true
+			message.EditTime = msg.EditTime.UnixMilli()
+		}
+		if !msg.ExpireAt.IsZero() {
+			message.ExpireTime = msg.ExpireAt.UnixMilli()
+		}
+		message.Reactions = reactionMap[msg.ID]
 		messageResp.Message = message
 
 		messageResp.SendTime = msg.CreateTime.UnixNano()
+		messageResp.Blocked = slices.Contains(blockedUids, msg.FromUID)
 
 		messageRespList = append(messageRespList, messageResp)
 	}
@@ -0,0 +1,187 @@
+package service
+
+import (
+	"DiTing-Go/dal/model"
+	"DiTing-Go/dal/query"
+	domainEnum "DiTing-Go/domain/enum"
+	"DiTing-Go/domain/vo/req"
+	"DiTing-Go/domain/vo/resp"
+	"DiTing-Go/global"
+	pkgEnum "DiTing-Go/pkg/domain/enum"
+	pkgResp "DiTing-Go/pkg/domain/vo/resp"
+	"DiTing-Go/pkg/utils"
+	"DiTing-Go/utils/redisCache"
+	"context"
+	"fmt"
+	"github.com/pkg/errors"
+	"gorm.io/gorm"
+	"slices"
+)
+
+// getBlockedUids 查询用户拉黑的uid列表，优先走缓存
+func getBlockedUids(uid int64) ([]int64, error) {
+	userBlock := global.Query.UserBlock
+	userBlockQ := userBlock.WithContext(context.Background())
+	fun := func() (interface{}, error) {
+		uids := make([]int64, 0)
+		err := userBlockQ.Where(userBlock.UID.Eq(uid), userBlock.DeleteStatus.Eq(pkgEnum.NORMAL)).Pluck(userBlock.TargetUID, &uids)
+		return uids, err
+	}
+	uids := make([]int64, 0)
+	key := fmt.Sprintf(domainEnum.UserBlockCacheByUid, uid)
+	if err := utils.GetData(key, &uids, fun); err != nil {
+		global.Logger.Errorf("查询黑名单失败 %s", err)
+		return nil, err
+	}
+	return uids, nil
+}
+
+// IsBlocked 判断两个用户之间是否存在拉黑关系，任意一方拉黑对方都算
+func IsBlocked(uid, targetUid int64) (bool, error) {
+	blockedUids, err := getBlockedUids(uid)
+	if err != nil {
+		return false, err
+	}
+	if slices.Contains(blockedUids, targetUid) {
+		return true, nil
+	}
+	blockedUids, err = getBlockedUids(targetUid)
+	if err != nil {
+		return false, err
+	}
+	return slices.Contains(blockedUids, uid), nil
+}
+
+// BlockUserService 拉黑用户
+func BlockUserService(uid int64, blockReq req.BlockUserReq) (pkgResp.ResponseData, error) {
+	ctx := context.Background()
+	if blockReq.Uid == uid {
+		return pkgResp.ErrorResponseData("不能拉黑自己"), errors.New("Business Error")
+	}
+	if _, err := getUserByID(blockReq.Uid); err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return pkgResp.ErrorResponseData("用户不存在"), errors.New("Business Error")
+		}
+		global.Logger.Errorf("查询用户失败 %s", err)
+		return pkgResp.ErrorResponseData("系统繁忙，请稍后再试~"), errors.New("Business Error")
+	}
+
+	userBlock := global.Query.UserBlock
+	userBlockQ := userBlock.WithContext(ctx)
+	// 之前移出过黑名单的记录直接恢复
+	blockR, err := userBlockQ.Where(userBlock.UID.Eq(uid), userBlock.TargetUID.Eq(blockReq.Uid)).First()
+	if err != nil && !errors.Is(err, gorm.ErrRecordNotFound) {
+		global.Logger.Errorf("查询黑名单失败 %s", err)
+		return pkgResp.ErrorResponseData("系统繁忙，请稍后再试~"), errors.New("Business Error")
+	}
+	if blockR == nil {
+		err = userBlockQ.Create(&model.UserBlock{
+			UID:          uid,
+			TargetUID:    blockReq.Uid,
+			DeleteStatus: pkgEnum.NORMAL,
+		})
+	} else if blockR.DeleteStatus != pkgEnum.NORMAL {
+		_, err = userBlockQ.Where(userBlock.ID.Eq(blockR.ID)).Update(userBlock.DeleteStatus, pkgEnum.NORMAL)
+	}
+	if err != nil {
+		global.Logger.Errorf("拉黑用户失败 %s", err)
+		return pkgResp.ErrorResponseData("系统繁忙，请稍后再试~"), errors.New("Business Error")
+	}
+	redisCache.RemoveUserBlock(uid)
+	redisCache.RemoveUserRecommend(uid, blockReq.Uid)
+	return pkgResp.SuccessResponseDataWithMsg("已拉黑"), nil
+}
+
+// UnblockUserService 将用户移出黑名单
+func UnblockUserService(uid int64, unblockReq req.UnblockUserReq) (pkgResp.ResponseData, error) {
+	userBlock := global.Query.UserBlock
+	userBlockQ := userBlock.WithContext(context.Background())
+	if _, err := userBlockQ.Where(userBlock.UID.Eq(uid), userBlock.TargetUID.Eq(unblockReq.Uid)).Update(userBlock.DeleteStatus, pkgEnum.DELETED); err != nil {
+		global.Logger.Errorf("移出黑名单失败 %s", err)
+		return pkgResp.ErrorResponseData("系统繁忙，请稍后再试~"), errors.New("Business Error")
+	}
+	redisCache.RemoveUserBlock(uid)
+	redisCache.RemoveUserRecommend(uid, unblockReq.Uid)
+	return pkgResp.SuccessResponseDataWithMsg("已移出黑名单"), nil
+}
+
+// GetBlockListService 获取黑名单列表
+func GetBlockListService(uid int64) (pkgResp.ResponseData, error) {
+	ctx := context.Background()
+	userBlock := global.Query.UserBlock
+	userBlockQ := userBlock.WithContext(ctx)
+	blockList, err := userBlockQ.Where(userBlock.UID.Eq(uid), userBlock.DeleteStatus.Eq(pkgEnum.NORMAL)).Order(userBlock.UpdateTime.Desc()).Find()
+	if err != nil {
+		global.Logger.Errorf("查询黑名单失败 %s", err)
+		return pkgResp.ErrorResponseData("系统繁忙，请稍后再试~"), errors.New("Business Error")
+	}
+	uids := make([]int64, 0, len(blockList))
+	for _, block := range blockList {
+		uids = append(uids, block.TargetUID)
+	}
+	user := global.Query.User
+	userQ := user.WithContext(ctx)
+	users, err := userQ.Where(user.ID.In(uids...)).Find()
+	if err != nil {
+		global.Logger.Errorf("查询用户失败 %s", err)
+		return pkgResp.ErrorResponseData("系统繁忙，请稍后再试~"), errors.New("Business Error")
+	}
+	userMap := make(map[int64]*model.User)
+	for _, userR := range users {
+		userMap[userR.ID] = userR
+	}
+
+	blockRespList := make([]resp.BlockUserResp, 0, len(blockList))
+	for _, block := range blockList {
+		blockResp := resp.BlockUserResp{
+			Uid:       block.TargetUID,
+			BlockTime: block.UpdateTime,
+		}
+		if userR, ok := userMap[block.TargetUID]; ok {
+			blockResp.Name = userR.Name
+			blockResp.Avatar = userR.Avatar
+		}
+		blockRespList = append(blockRespList, blockResp)
+	}
+	return pkgResp.SuccessResponseData(blockRespList), nil
+}
+
+// IsRoomBlocked 判断单聊房间的双方是否存在拉黑关系，群聊不受影响
+func IsRoomBlocked(uid, roomId int64) (bool, error) {
+	ctx := context.Background()
+	roomQ := global.Query.WithContext(ctx).Room
+	fun := func() (interface{}, error) {
+		return roomQ.Where(query.Room.ID.Eq(roomId)).First()
+	}
+	roomR := model.Room{}
+	key := fmt.Sprintf(domainEnum.RoomCacheByID, roomId)
+	if err := utils.GetData(key, &roomR, fun); err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return false, nil
+		}
+		global.Logger.Errorf("查询房间失败 %s", err)
+		return false, err
+	}
+	if roomR.Type != domainEnum.PERSONAL {
+		return false, nil
+	}
+
+	roomFriendQ := global.Query.WithContext(ctx).RoomFriend
+	fun = func() (interface{}, error) {
+		return roomFriendQ.Where(query.RoomFriend.RoomID.Eq(roomId)).First()
+	}
+	roomFriendR := model.RoomFriend{}
+	key = fmt.Sprintf(domainEnum.RoomFriendCacheByRoomID, roomId)
+	if err := utils.GetData(key, &roomFriendR, fun); err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return false, nil
+		}
+		global.Logger.Errorf("查询好友房间失败 %s", err)
+		return false, err
+	}
+	targetUid := roomFriendR.Uid1
+	if targetUid == uid {
+		targetUid = roomFriendR.Uid2
+	}
+	return IsBlocked(uid, targetUid)
+}
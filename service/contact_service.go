@@ -97,6 +97,11 @@ func GetContactListService(uid int64, pageReq pkgReq.PageReq) (pkgResp.ResponseD
 		global.Logger.Errorf("查询用户失败 %s", err)
 		return pkgResp.ErrorResponseData("系统繁忙，请稍后再试~"), errors.New("Business Error")
 	}
+	// 单聊优先展示好友备注
+	remarkMap, err := getFriendRemarkMap(uid, uidList)
+	if err != nil {
+		return pkgResp.ErrorResponseData("系统繁忙，请稍后再试~"), errors.New("Business Error")
+	}
 
 	// 查询群聊房间信息
 	roomGroup := global.Query.RoomGroup
@@ -149,7 +154,7 @@ func GetContactListService(uid int64, pageReq pkgReq.PageReq) (pkgResp.ResponseD
 	}
 
 	// 拼装结果
-	contactDaoList := adapter.BuildContactDaoList(*contactList, userRList, msgRList, roomRList, roomFriendRList, roomGroupRList, countMap)
+	contactDaoList := adapter.BuildContactDaoList(*contactList, userRList, msgRList, roomRList, roomFriendRList, roomGroupRList, countMap, remarkMap)
 
 	pageResp.Data = contactDaoList
 	return pkgResp.SuccessResponseData(pageResp), nil
@@ -219,6 +224,11 @@ func GetNewContactListService(uid int64, timestamp int64) (pkgResp.ResponseData,
 		global.Logger.Errorf("查询用户失败 %s", err)
 		return pkgResp.ErrorResponseData("系统繁忙，请稍后再试~"), errors.New("Business Error")
 	}
+	// 单聊优先展示好友备注
+	remarkMap, err := getFriendRemarkMap(uid, uidList)
+	if err != nil {
+		return pkgResp.ErrorResponseData("系统繁忙，请稍后再试~"), errors.New("Business Error")
+	}
 
 	// 查询群聊房间信息
 	roomGroup := global.Query.RoomGroup
@@ -271,7 +281,7 @@ func GetNewContactListService(uid int64, timestamp int64) (pkgResp.ResponseData,
 	}
 
 	// 拼装结果
-	contactDaoList := adapter.BuildContactDaoList(temp, userRList, msgRList, roomRList, roomFriendRList, roomGroupRList, countMap)
+	contactDaoList := adapter.BuildContactDaoList(temp, userRList, msgRList, roomRList, roomFriendRList, roomGroupRList, countMap, remarkMap)
 
 	return pkgResp.SuccessResponseData(contactDaoList), nil
 }
@@ -316,7 +326,7 @@ func GetContactDetailService(c *gin.Context) {
 	}
 
 	// 获取会话详情
-	pageResp, err := GetContactDetail(roomId, pageRequest)
+	pageResp, err := GetContactDetail(uid, roomId, pageRequest)
 	if err != nil {
 		global.Logger.Errorf("查询会话详情失败 %s", err)
 		resp.ErrorResponse(c, "系统正忙，请稍后再试")
@@ -327,7 +337,7 @@ func GetContactDetailService(c *gin.Context) {
 	return
 }
 
-func GetContactDetail(roomID int64, pageRequest pkgReq.PageReq) (*pkgResp.PageResp, error) {
+func GetContactDetail(uid int64, roomID int64, pageRequest pkgReq.PageReq) (*pkgResp.PageResp, error) {
 	// 查询消息
 	db := dal.DB
 	msgs := make([]model.Message, 0)
@@ -366,11 +376,26 @@ func GetContactDetail(roomID int64, pageRequest pkgReq.PageReq) (*pkgResp.PageRe
 		userMap[user.ID] = user
 	}
 
+	// 被当前用户拉黑的发送者，消息标记后由客户端隐藏
+	blockedUids, err := getBlockedUids(uid)
+	if err != nil {
+		return nil, err
+	}
+
+	msgIdList := make([]int64, 0, len(msgList))
+	for _, msg := range msgList {
+		msgIdList = append(msgIdList, msg.ID)
+	}
+	reactionMap, err := getMsgReactionMap(uid, msgIdList)
+	if err != nil {
+		return nil, err
+	}
+
 	// 拼装结果
-	pageResp.Data = adapter.BuildMessageRespByMsgAndUser(&msgList, userMap)
+	pageResp.Data = adapter.BuildMessageRespByMsgAndUser(&msgList, userMap, blockedUids, reactionMap)
 	return pageResp, nil
 }
-func GetNewMsgService(msgId int64, roomId int64) (pkgResp.ResponseData, error) {
+func GetNewMsgService(uid int64, msgId int64, roomId int64) (pkgResp.ResponseData, error) {
 	ctx := context.Background()
 	// 查询消息
 	msg := global.Query.Message
@@ -411,8 +436,23 @@ func GetNewMsgService(msgId int64, roomId int64) (pkgResp.ResponseData, error) {
 		temp = append(temp, *msg)
 	}
 
+	// 被当前用户拉黑的发送者，消息标记后由客户端隐藏
+	blockedUids, err := getBlockedUids(uid)
+	if err != nil {
+		return pkgResp.ErrorResponseData("接收消息失败"), err
+	}
+
+	msgIdList := make([]int64, 0, len(temp))
+	for _, msg := range temp {
+		msgIdList = append(msgIdList, msg.ID)
+	}
+	reactionMap, err := getMsgReactionMap(uid, msgIdList)
+	if err != nil {
+		return pkgResp.ErrorResponseData("接收消息失败"), err
+	}
+
 	// 拼装结果
-	data := adapter.BuildMessageRespByMsgAndUser(&temp, userMap)
+	data := adapter.BuildMessageRespByMsgAndUser(&temp, userMap, blockedUids, reactionMap)
 	return pkgResp.SuccessResponseData(data), nil
 }
 
@@ -456,6 +496,8 @@ func GetUserInfoBatchService(reqList req.GetUserInfoBatchReq) (pkgResp.ResponseD
 		if user.UpdateTime.UnixMilli() > userMap[user.ID].LastModifyTime {
 			resultItem.Username = user.Name
 			resultItem.Avatar = user.Avatar
+			resultItem.Sex = user.Sex
+			resultItem.Signature = user.Signature
 			resultItem.NeedRefresh = true
 		} else {
 			resultItem.NeedRefresh = false
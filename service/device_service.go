@@ -0,0 +1,152 @@
+package service
+
+import (
+	"DiTing-Go/dal/model"
+	"DiTing-Go/domain/dto"
+	domainEnum "DiTing-Go/domain/enum"
+	"DiTing-Go/domain/vo/req"
+	"DiTing-Go/domain/vo/resp"
+	"DiTing-Go/global"
+	pkgEnum "DiTing-Go/pkg/domain/enum"
+	pkgResp "DiTing-Go/pkg/domain/vo/resp"
+	"DiTing-Go/pkg/utils"
+	"DiTing-Go/utils/redisCache"
+	"context"
+	"github.com/goccy/go-json"
+	"github.com/pkg/errors"
+	"github.com/spf13/viper"
+	"gorm.io/gorm"
+	"time"
+)
+
+// getDevicePolicy 多端登录策略，默认不限制
+func getDevicePolicy() string {
+	if viper.GetString("device.policy") == domainEnum.DevicePolicySingle {
+		return domainEnum.DevicePolicySingle
+	}
+	return domainEnum.DevicePolicyMulti
+}
+
+// registerDevice 登录成功后登记设备，按策略踢下同平台的旧会话
+func registerDevice(userR *model.User, sid string, platform int32, deviceName string, ip string) error {
+	ctx := context.Background()
+	userDevice := global.Query.UserDevice
+	userDeviceQ := userDevice.WithContext(ctx)
+
+	if platform == 0 {
+		platform = domainEnum.WebPlatform
+	}
+	if getDevicePolicy() == domainEnum.DevicePolicySingle {
+		oldDevices, err := userDeviceQ.Where(userDevice.UID.Eq(userR.ID), userDevice.Platform.Eq(platform), userDevice.Status.Eq(pkgEnum.NORMAL)).Find()
+		if err != nil {
+			global.Logger.Errorf("查询设备失败 %s", err)
+			return err
+		}
+		for _, oldDevice := range oldDevices {
+			if err := revokeSession(oldDevice.UID, oldDevice.Sid); err != nil {
+				return err
+			}
+		}
+	}
+
+	if err := userDeviceQ.Create(&model.UserDevice{
+		UID:          userR.ID,
+		Sid:          sid,
+		Platform:     platform,
+		Name:         deviceName,
+		IP:           ip,
+		Status:       pkgEnum.NORMAL,
+		LastSeenTime: time.Now(),
+	}); err != nil {
+		global.Logger.Errorf("登记设备失败 %s", err)
+		return err
+	}
+	updateUserIpInfo(userR, ip)
+	return nil
+}
+
+// updateUserIpInfo 记录用户登录ip，失败不影响登录
+func updateUserIpInfo(userR *model.User, ip string) {
+	ipInfo := dto.IpInfo{}
+	_ = json.Unmarshal([]byte(userR.IPInfo), &ipInfo)
+	if ipInfo.CreateIp == "" {
+		ipInfo.CreateIp = ip
+	}
+	ipInfo.UpdateIp = ip
+	ipInfoByte, err := json.Marshal(ipInfo)
+	if err != nil {
+		global.Logger.Errorf("json序列化失败 %s", err)
+		return
+	}
+	user := global.Query.User
+	userQ := user.WithContext(context.Background())
+	if _, err := userQ.Where(user.ID.Eq(userR.ID)).Update(user.IPInfo, string(ipInfoByte)); err != nil {
+		global.Logger.Errorf("更新用户ip信息失败 %s", err)
+		return
+	}
+	redisCache.RemoveUserCache(*userR)
+}
+
+// touchDevice 刷新设备最后活跃时间
+func touchDevice(sid string) {
+	userDevice := global.Query.UserDevice
+	userDeviceQ := userDevice.WithContext(context.Background())
+	if _, err := userDeviceQ.Where(userDevice.Sid.Eq(sid)).Update(userDevice.LastSeenTime, time.Now()); err != nil {
+		global.Logger.Errorf("更新设备活跃时间失败 %s", err)
+	}
+}
+
+// offlineDevice 会话吊销后将设备标记为下线
+func offlineDevice(sid string) {
+	userDevice := global.Query.UserDevice
+	userDeviceQ := userDevice.WithContext(context.Background())
+	if _, err := userDeviceQ.Where(userDevice.Sid.Eq(sid)).Update(userDevice.Status, pkgEnum.DELETED); err != nil {
+		global.Logger.Errorf("更新设备状态失败 %s", err)
+	}
+}
+
+// GetDeviceListService 获取登录设备列表
+func GetDeviceListService(uid int64, sid string) (pkgResp.ResponseData, error) {
+	ctx := context.Background()
+	userDevice := global.Query.UserDevice
+	userDeviceQ := userDevice.WithContext(ctx)
+	// 超过刷新令牌有效期未活跃的会话已自然过期
+	activeTime := time.Now().Add(-utils.GetRefreshTokenExpire())
+	devices, err := userDeviceQ.Where(userDevice.UID.Eq(uid), userDevice.Status.Eq(pkgEnum.NORMAL), userDevice.LastSeenTime.Gt(activeTime)).Order(userDevice.LastSeenTime.Desc()).Find()
+	if err != nil {
+		global.Logger.Errorf("查询设备失败 %s", err)
+		return pkgResp.ErrorResponseData("系统繁忙，请稍后再试~"), errors.New("Business Error")
+	}
+	deviceRespList := make([]resp.DeviceResp, 0, len(devices))
+	for _, device := range devices {
+		deviceRespList = append(deviceRespList, resp.DeviceResp{
+			ID:           device.ID,
+			Platform:     device.Platform,
+			Name:         device.Name,
+			Ip:           device.IP,
+			LastSeenTime: device.LastSeenTime,
+			CreateTime:   device.CreateTime,
+			Current:      device.Sid == sid,
+		})
+	}
+	return pkgResp.SuccessResponseData(deviceRespList), nil
+}
+
+// KickDeviceService 踢下指定设备，吊销其会话并断开连接
+func KickDeviceService(uid int64, kickReq req.KickDeviceReq) (pkgResp.ResponseData, error) {
+	ctx := context.Background()
+	userDevice := global.Query.UserDevice
+	userDeviceQ := userDevice.WithContext(ctx)
+	device, err := userDeviceQ.Where(userDevice.ID.Eq(kickReq.ID), userDevice.UID.Eq(uid), userDevice.Status.Eq(pkgEnum.NORMAL)).First()
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return pkgResp.ErrorResponseData("设备不存在"), errors.New("Business Error")
+		}
+		global.Logger.Errorf("查询设备失败 %s", err)
+		return pkgResp.ErrorResponseData("系统繁忙，请稍后再试~"), errors.New("Business Error")
+	}
+	if err := revokeSession(uid, device.Sid); err != nil {
+		return pkgResp.ErrorResponseData("系统繁忙，请稍后再试~"), errors.New("Business Error")
+	}
+	return pkgResp.SuccessResponseDataWithMsg("success"), nil
+}
@@ -0,0 +1,220 @@
+package service
+
+import (
+	"DiTing-Go/dal"
+	"DiTing-Go/dal/model"
+	"DiTing-Go/domain/enum"
+	"DiTing-Go/domain/vo/req"
+	domainResp "DiTing-Go/domain/vo/resp"
+	"DiTing-Go/global"
+	pkgEnum "DiTing-Go/pkg/domain/enum"
+	pkgReq "DiTing-Go/pkg/domain/vo/req"
+	"DiTing-Go/pkg/domain/vo/resp"
+	"DiTing-Go/pkg/utils"
+	"context"
+	"encoding/json"
+	"github.com/pkg/errors"
+	"gorm.io/gorm"
+	"slices"
+	"strings"
+	"time"
+)
+
+// AddFavoriteService 收藏消息，保存消息快照，原消息删除或退出房间后收藏仍然可见
+func AddFavoriteService(uid int64, favoriteReq req.AddFavoriteReq) (resp.ResponseData, error) {
+	ctx := context.Background()
+	message := global.Query.Message
+	msg, err := message.WithContext(ctx).Where(message.ID.Eq(favoriteReq.MsgId)).First()
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return resp.ErrorResponseData("消息不存在"), errors.New("Business Error")
+		}
+		global.Logger.Errorf("查询消息失败 %s", err)
+		return resp.ErrorResponseData("系统繁忙，请稍后再试~"), errors.New("Business Error")
+	}
+	if msg.DeleteStatus != pkgEnum.NORMAL {
+		return resp.ErrorResponseData("消息不存在"), errors.New("Business Error")
+	}
+	if msg.Type == enum.SystemMessageType {
+		return resp.ErrorResponseData("该消息不能收藏"), errors.New("Business Error")
+	}
+	isMember, err := IsRoomMember(uid, msg.RoomID)
+	if err != nil {
+		return resp.ErrorResponseData("系统繁忙，请稍后再试~"), errors.New("Business Error")
+	}
+	if !isMember {
+		return resp.ErrorResponseData("消息不存在"), errors.New("Business Error")
+	}
+
+	userFavorite := global.Query.UserFavorite
+	userFavoriteQ := userFavorite.WithContext(ctx)
+	exist, err := userFavoriteQ.Where(userFavorite.UID.Eq(uid), userFavorite.MsgID.Eq(msg.ID)).Count()
+	if err != nil {
+		global.Logger.Errorf("查询收藏失败 %s", err)
+		return resp.ErrorResponseData("系统繁忙，请稍后再试~"), errors.New("Business Error")
+	}
+	if exist > 0 {
+		return resp.SuccessResponseDataWithMsg("已收藏"), nil
+	}
+
+	favorite := model.UserFavorite{
+		UID:        uid,
+		MsgID:      msg.ID,
+		RoomID:     msg.RoomID,
+		FromUID:    msg.FromUID,
+		MsgType:    msg.Type,
+		Content:    msg.Content,
+		Extra:      msg.Extra,
+		SendTime:   msg.CreateTime,
+		Tags:       strings.Join(favoriteReq.Tags, enum.FavoriteTagSeparator),
+		Note:       favoriteReq.Note,
+		CreateTime: time.Now(),
+	}
+	if favorite.Extra == "" {
+		favorite.Extra = "{}"
+	}
+	// 发送者的昵称和头像也保存快照
+	fromUser, err := getUserByID(msg.FromUID)
+	if err != nil && !errors.Is(err, gorm.ErrRecordNotFound) {
+		global.Logger.Errorf("查询用户失败 %s", err)
+		return resp.ErrorResponseData("系统繁忙，请稍后再试~"), errors.New("Business Error")
+	}
+	if fromUser != nil {
+		favorite.FromName = fromUser.Name
+		favorite.FromAvatar = fromUser.Avatar
+	}
+	tx := global.Query.Begin()
+	if err := tx.UserFavorite.WithContext(ctx).Create(&favorite); err != nil {
+		if err := tx.Rollback(); err != nil {
+			global.Logger.Errorf("事务回滚失败 %s", err.Error())
+		}
+		// 并发重复收藏时唯一索引冲突
+		if exist, _ := userFavoriteQ.Where(userFavorite.UID.Eq(uid), userFavorite.MsgID.Eq(msg.ID)).Count(); exist > 0 {
+			return resp.SuccessResponseDataWithMsg("已收藏"), nil
+		}
+		global.Logger.Errorf("保存收藏失败 %s", err)
+		return resp.ErrorResponseData("系统繁忙，请稍后再试~"), errors.New("Business Error")
+	}
+	// 收藏的快照引用原文件，原消息删除后文件仍然保留
+	if err := saveObjectRefs(tx, enum.ObjectRefFavorite, favorite.ID, msgObjectNames(favorite.MsgType, favorite.Extra)); err != nil {
+		if err := tx.Rollback(); err != nil {
+			global.Logger.Errorf("事务回滚失败 %s", err.Error())
+		}
+		return resp.ErrorResponseData("系统繁忙，请稍后再试~"), errors.New("Business Error")
+	}
+	if err := tx.Commit(); err != nil {
+		global.Logger.Errorf("事务提交失败 %s", err.Error())
+		return resp.ErrorResponseData("系统繁忙，请稍后再试~"), errors.New("Business Error")
+	}
+	return resp.SuccessResponseData(buildFavoriteResp(&favorite)), nil
+}
+
+// UpdateFavoriteService 修改收藏的标签和备注
+func UpdateFavoriteService(uid int64, favoriteReq req.UpdateFavoriteReq) (resp.ResponseData, error) {
+	userFavorite := global.Query.UserFavorite
+	userFavoriteQ := userFavorite.WithContext(context.Background())
+	if _, err := userFavoriteQ.Where(userFavorite.ID.Eq(favoriteReq.Id), userFavorite.UID.Eq(uid)).First(); err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return resp.ErrorResponseData("收藏不存在"), errors.New("Business Error")
+		}
+		global.Logger.Errorf("查询收藏失败 %s", err)
+		return resp.ErrorResponseData("系统繁忙，请稍后再试~"), errors.New("Business Error")
+	}
+	tags := strings.Join(favoriteReq.Tags, enum.FavoriteTagSeparator)
+	if _, err := userFavoriteQ.Where(userFavorite.ID.Eq(favoriteReq.Id), userFavorite.UID.Eq(uid)).UpdateSimple(userFavorite.Tags.Value(tags), userFavorite.Note.Value(favoriteReq.Note)); err != nil {
+		global.Logger.Errorf("修改收藏失败 %s", err)
+		return resp.ErrorResponseData("系统繁忙，请稍后再试~"), errors.New("Business Error")
+	}
+	return resp.SuccessResponseDataWithMsg("修改成功"), nil
+}
+
+// DeleteFavoriteService 取消收藏
+func DeleteFavoriteService(uid int64, favoriteReq req.DeleteFavoriteReq) (resp.ResponseData, error) {
+	userFavorite := global.Query.UserFavorite
+	userFavoriteQ := userFavorite.WithContext(context.Background())
+	result, err := userFavoriteQ.Where(userFavorite.ID.Eq(favoriteReq.Id), userFavorite.UID.Eq(uid)).Delete()
+	if err != nil {
+		global.Logger.Errorf("取消收藏失败 %s", err)
+		return resp.ErrorResponseData("系统繁忙，请稍后再试~"), errors.New("Business Error")
+	}
+	if result.RowsAffected > 0 {
+		releaseObjectRefs(enum.ObjectRefFavorite, []int64{favoriteReq.Id})
+	}
+	return resp.SuccessResponseDataWithMsg("已取消收藏"), nil
+}
+
+// GetFavoriteListService 分页获取收藏列表，支持按标签筛选和关键字搜索
+func GetFavoriteListService(uid int64, favoriteReq req.GetFavoriteListReq) (resp.ResponseData, error) {
+	pageReq := pkgReq.PageReq{
+		Cursor:   favoriteReq.Cursor,
+		PageSize: favoriteReq.PageSize,
+	}
+	if pageReq.PageSize <= 0 {
+		pageReq.PageSize = enum.FavoriteDefaultPageSize
+	}
+	condition := []interface{}{"uid = ?", uid}
+	if favoriteReq.Tag != "" {
+		condition[0] = condition[0].(string) + " and FIND_IN_SET(?, tags) > 0"
+		condition = append(condition, favoriteReq.Tag)
+	}
+	if keyword := strings.TrimSpace(favoriteReq.Keyword); keyword != "" {
+		like := "%" + likeEscaper.Replace(keyword) + "%"
+		condition[0] = condition[0].(string) + " and (content like ? or note like ? or from_name like ?)"
+		condition = append(condition, like, like, like)
+	}
+	favoriteList := make([]model.UserFavorite, 0)
+	pageResp, err := utils.Paginate(dal.DB, pageReq, &favoriteList, "id", false, condition...)
+	if err != nil {
+		global.Logger.Errorf("查询收藏失败 %s", err)
+		return resp.ErrorResponseData("系统繁忙，请稍后再试~"), errors.New("Business Error")
+	}
+	favoriteRespList := make([]domainResp.FavoriteResp, 0, len(favoriteList))
+	for i := range favoriteList {
+		favoriteRespList = append(favoriteRespList, buildFavoriteResp(&favoriteList[i]))
+	}
+	pageResp.Data = favoriteRespList
+	return resp.SuccessResponseData(pageResp), nil
+}
+
+// GetFavoriteDownloadUrlService 签发收藏中文件的下载链接，不要求仍在原房间中
+func GetFavoriteDownloadUrlService(uid int64, downloadReq req.FavoriteDownloadReq) (string, error) {
+	userFavorite := global.Query.UserFavorite
+	favorite, err := userFavorite.WithContext(context.Background()).Where(userFavorite.ID.Eq(downloadReq.Id), userFavorite.UID.Eq(uid)).First()
+	if err != nil {
+		if !errors.Is(err, gorm.ErrRecordNotFound) {
+			global.Logger.Errorf("查询收藏失败 %s", err)
+		}
+		return "", err
+	}
+	extraStr, err := pickDownloadExtra(favorite.MsgType, favorite.Extra, downloadReq.Index)
+	if err != nil {
+		return "", err
+	}
+	return presignedDownloadUrl(extraStr)
+}
+
+func buildFavoriteResp(favorite *model.UserFavorite) domainResp.FavoriteResp {
+	favoriteResp := domainResp.FavoriteResp{
+		Id:     favorite.ID,
+		MsgId:  favorite.MsgID,
+		RoomId: favorite.RoomID,
+		FromUser: domainResp.MsgUser{
+			Uid:      favorite.FromUID,
+			Username: favorite.FromName,
+			Avatar:   favorite.FromAvatar,
+		},
+		Type:         favorite.MsgType,
+		Content:      favorite.Content,
+		Tags:         []string{},
+		Note:         favorite.Note,
+		SendTime:     favorite.SendTime.UnixMilli(),
+		FavoriteTime: favorite.CreateTime.UnixMilli(),
+	}
+	if favorite.Tags != "" {
+		favoriteResp.Tags = strings.Split(favorite.Tags, enum.FavoriteTagSeparator)
+	}
+	if slices.Contains(enum.ExtraMessageTypes, favorite.MsgType) {
+		favoriteResp.Extra = json.RawMessage(favorite.Extra)
+	}
+	return favoriteResp
+}
@@ -0,0 +1,225 @@
+package service
+
+import (
+	"DiTing-Go/dal/model"
+	"DiTing-Go/domain/dto"
+	"DiTing-Go/domain/enum"
+	"DiTing-Go/domain/vo/req"
+	domainResp "DiTing-Go/domain/vo/resp"
+	"DiTing-Go/global"
+	pkgEnum "DiTing-Go/pkg/domain/enum"
+	"DiTing-Go/pkg/domain/vo/resp"
+	"context"
+	"github.com/goccy/go-json"
+	"github.com/pkg/errors"
+	"slices"
+)
+
+// forwardableTypes 允许转发的消息类型
+var forwardableTypes = []int32{enum.TextMessageType, enum.ImgMessageType, enum.ChatHistoryMessageType}
+
+// ForwardMsgService 转发消息，逐条转发复制原消息，合并转发生成一条聊天记录消息
+func ForwardMsgService(uid int64, forwardReq req.ForwardReq) (resp.ResponseData, error) {
+	ctx := context.Background()
+	if forwardReq.Type == enum.ForwardSingle && len(forwardReq.MsgIds) > enum.ForwardSingleLimit {
+		return resp.ErrorResponseData("逐条转发的消息过多，请使用合并转发"), errors.New("Business Error")
+	}
+	msgIds := uniqueIds(forwardReq.MsgIds)
+	roomIds := uniqueIds(forwardReq.RoomIds)
+
+	// 校验被转发的消息
+	message := global.Query.Message
+	msgList, err := message.WithContext(ctx).Where(message.ID.In(msgIds...), message.DeleteStatus.Eq(pkgEnum.NORMAL)).Order(message.ID).Find()
+	if err != nil {
+		global.Logger.Errorf("查询消息失败 %s", err)
+		return resp.ErrorResponseData("系统繁忙，请稍后再试~"), errors.New("Business Error")
+	}
+	if len(msgList) != len(msgIds) {
+		return resp.ErrorResponseData("消息不存在"), errors.New("Business Error")
+	}
+	sourceRoomId := msgList[0].RoomID
+	for _, msg := range msgList {
+		if msg.RoomID != sourceRoomId {
+			return resp.ErrorResponseData("只能转发同一会话中的消息"), errors.New("Business Error")
+		}
+		if !slices.Contains(forwardableTypes, msg.Type) {
+			return resp.ErrorResponseData("该消息不支持转发"), errors.New("Business Error")
+		}
+	}
+	isMember, err := IsRoomMember(uid, sourceRoomId)
+	if err != nil {
+		return resp.ErrorResponseData("系统繁忙，请稍后再试~"), errors.New("Business Error")
+	}
+	if !isMember {
+		return resp.ErrorResponseData("消息不存在"), errors.New("Business Error")
+	}
+
+	// 校验目标房间
+	for _, roomId := range roomIds {
+		isMember, err := IsRoomMember(uid, roomId)
+		if err != nil {
+			return resp.ErrorResponseData("系统繁忙，请稍后再试~"), errors.New("Business Error")
+		}
+		if !isMember {
+			return resp.ErrorResponseData("无权向该会话发送消息"), errors.New("Business Error")
+		}
+		isBlocked, err := IsRoomBlocked(uid, roomId)
+		if err != nil {
+			return resp.ErrorResponseData("系统繁忙，请稍后再试~"), errors.New("Business Error")
+		}
+		if isBlocked {
+			return resp.ErrorResponseDataWithCode(pkgEnum.ERROR_USER_BLOCKED, nil), errors.New("Business Error")
+		}
+	}
+
+	userR, err := getUserByID(uid)
+	if err != nil {
+		global.Logger.Errorf("查询用户失败 %s", err)
+		return resp.ErrorResponseData("系统繁忙，请稍后再试~"), errors.New("Business Error")
+	}
+	// 合并转发的聊天记录对所有目标房间相同，只生成一次
+	chatHistory := ""
+	if forwardReq.Type == enum.ForwardMerge {
+		chatHistory, err = buildChatHistory(sourceRoomId, msgList)
+		if err != nil {
+			if errors.Is(err, errChatHistoryTooLarge) {
+				return resp.ErrorResponseData(err.Error()), errors.New("Business Error")
+			}
+			return resp.ErrorResponseData("系统繁忙，请稍后再试~"), errors.New("Business Error")
+		}
+	}
+
+	newMsgList := make([]*model.Message, 0)
+	for _, roomId := range roomIds {
+		if forwardReq.Type == enum.ForwardMerge {
+			newMsgList = append(newMsgList, &model.Message{
+				RoomID:    roomId,
+				FromUID:   uid,
+				Content:   enum.ChatHistoryContent,
+				Type:      enum.ChatHistoryMessageType,
+				Extra:     chatHistory,
+				Forwarded: pkgEnum.YES,
+			})
+			continue
+		}
+		for _, msg := range msgList {
+			newMsgList = append(newMsgList, &model.Message{
+				RoomID:    roomId,
+				FromUID:   uid,
+				Content:   msg.Content,
+				Type:      msg.Type,
+				Extra:     msg.Extra,
+				Forwarded: pkgEnum.YES,
+			})
+		}
+	}
+
+	// 所有消息在同一事务中保存，要么全部转发成功，要么全部失败
+	tx := global.Query.Begin()
+	for _, newMsg := range newMsgList {
+		if newMsg.Extra == "" {
+			newMsg.Extra = "{}"
+		}
+		if err := SendTextMsgTx(tx, newMsg); err != nil {
+			if err := tx.Rollback(); err != nil {
+				global.Logger.Errorf("事务回滚失败 %s", err.Error())
+			}
+			return resp.ErrorResponseData("消息转发失败"), errors.New("Business Error")
+		}
+	}
+	if err := tx.Commit(); err != nil {
+		global.Logger.Errorf("事务提交失败 %s", err.Error())
+		return resp.ErrorResponseData("消息转发失败"), errors.New("Business Error")
+	}
+
+	msgRespList := make([]domainResp.MessageResp, 0, len(newMsgList))
+	for _, newMsg := range newMsgList {
+		msgRespList = append(msgRespList, buildMessageResp(userR, newMsg))
+	}
+	return resp.SuccessResponseData(msgRespList), nil
+}
+
+var errChatHistoryTooLarge = errors.New("聊天记录内容过多，请减少转发的消息")
+
+// buildChatHistory 生成合并转发的消息快照，保存发送者当时的昵称和头像
+// 被转发的聊天记录只保存摘要，避免多次合并转发后快照不断膨胀
+func buildChatHistory(roomId int64, msgList []*model.Message) (string, error) {
+	uids := make([]int64, 0, len(msgList))
+	for _, msg := range msgList {
+		uids = append(uids, msg.FromUID)
+	}
+	user := global.Query.User
+	users, err := user.WithContext(context.Background()).Select(user.ID, user.Name, user.Avatar).Where(user.ID.In(uids...)).Find()
+	if err != nil {
+		global.Logger.Errorf("查询用户失败 %s", err)
+		return "", err
+	}
+	userMap := make(map[int64]*model.User)
+	for _, userR := range users {
+		userMap[userR.ID] = userR
+	}
+
+	chatHistory := dto.ChatHistoryDto{
+		RoomId:   roomId,
+		Messages: make([]dto.ChatHistoryItemDto, 0, len(msgList)),
+	}
+	for _, msg := range msgList {
+		extra, err := chatHistoryItemExtra(msg)
+		if err != nil {
+			return "", err
+		}
+		item := dto.ChatHistoryItemDto{
+			MsgId:    msg.ID,
+			FromUid:  msg.FromUID,
+			Type:     msg.Type,
+			Content:  msg.Content,
+			Extra:    extra,
+			SendTime: msg.CreateTime.UnixMilli(),
+		}
+		if userR, ok := userMap[msg.FromUID]; ok {
+			item.Name = userR.Name
+			item.Avatar = userR.Avatar
+		}
+		chatHistory.Messages = append(chatHistory.Messages, item)
+	}
+	chatHistoryByte, err := json.Marshal(chatHistory)
+	if err != nil {
+		global.Logger.Errorf("json序列化失败 %s", err)
+		return "", err
+	}
+	if len(chatHistoryByte) > enum.ChatHistoryMaxSize {
+		return "", errChatHistoryTooLarge
+	}
+	return string(chatHistoryByte), nil
+}
+
+// chatHistoryItemExtra 聊天记录中单条消息的扩展信息，嵌套的聊天记录替换为摘要
+func chatHistoryItemExtra(msg *model.Message) (json.RawMessage, error) {
+	if msg.Extra == "" {
+		return json.RawMessage("{}"), nil
+	}
+	if msg.Type != enum.ChatHistoryMessageType {
+		return json.RawMessage(msg.Extra), nil
+	}
+	chatHistory := dto.ChatHistoryDto{}
+	if err := json.Unmarshal([]byte(msg.Extra), &chatHistory); err != nil {
+		global.Logger.Errorf("json反序列化失败 %s", err)
+		return nil, err
+	}
+	summary, err := json.Marshal(dto.ChatHistorySummaryDto{
+		RoomId: chatHistory.RoomId,
+		Count:  len(chatHistory.Messages),
+	})
+	if err != nil {
+		global.Logger.Errorf("json序列化失败 %s", err)
+		return nil, err
+	}
+	return summary, nil
+}
+
+// uniqueIds 排序并去重
+func uniqueIds(ids []int64) []int64 {
+	ids = slices.Clone(ids)
+	slices.Sort(ids)
+	return slices.Compact(ids)
+}
@@ -0,0 +1,124 @@
+package service
+
+import (
+	"DiTing-Go/dal/model"
+	domainEnum "DiTing-Go/domain/enum"
+	"DiTing-Go/global"
+	pkgEnum "DiTing-Go/pkg/domain/enum"
+	"DiTing-Go/pkg/domain/vo/resp"
+	"DiTing-Go/pkg/utils"
+	"DiTing-Go/utils/redisCache"
+	"context"
+	"fmt"
+	"github.com/go-redsync/redsync/v4"
+	"github.com/pkg/errors"
+	"github.com/spf13/viper"
+	"gorm.io/gorm"
+	"sort"
+	"time"
+)
+
+// getApplyExpire 好友申请有效期，配置项 friendApply.expire
+func getApplyExpire() time.Duration {
+	if expire := viper.GetDuration("friendApply.expire"); expire > 0 {
+		return expire
+	}
+	return domainEnum.DefaultApplyExpire
+}
+
+// getApplyCooldown 被拒绝或过期后再次申请的冷却时间，配置项 friendApply.cooldown
+func getApplyCooldown() time.Duration {
+	if cooldown := viper.GetDuration("friendApply.cooldown"); cooldown > 0 {
+		return cooldown
+	}
+	return domainEnum.DefaultApplyCooldown
+}
+
+// RejectFriendService 拒绝好友申请
+func RejectFriendService(uid, friendUid int64) (resp.ResponseData, error) {
+	uids := utils.Int64Slice{uid, friendUid}
+	sort.Sort(uids)
+	mutex, err := utils.GetLock(fmt.Sprintf(domainEnum.UserAndFriendLock, uids[0], uids[1]))
+	if err != nil {
+		return resp.ErrorResponseData("系统正忙，请稍后再试"), err
+	}
+	defer utils.ReleaseLock(mutex)
+
+	ctx := context.Background()
+	userApply := global.Query.UserApply
+	userApplyQ := userApply.WithContext(ctx)
+	userApplyR, err := userApplyQ.Where(userApply.UID.Eq(friendUid), userApply.TargetID.Eq(uid)).First()
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return resp.ErrorResponseData("好友申请不存在"), errors.New("Business Error")
+		}
+		global.Logger.Errorf("查询好友申请失败 %s", err)
+		return resp.ErrorResponseData("系统正忙，请稍后再试"), errors.New("Business Error")
+	}
+	if userApplyR.Status != domainEnum.ApplyWaiting {
+		return resp.ErrorResponseData("好友申请已处理"), errors.New("Business Error")
+	}
+	if _, err := userApplyQ.Where(userApply.ID.Eq(userApplyR.ID)).UpdateSimple(
+		userApply.Status.Value(domainEnum.ApplyRejected),
+		userApply.ReadStatus.Value(pkgEnum.YES),
+	); err != nil {
+		global.Logger.Errorf("拒绝好友申请失败 %s", err)
+		return resp.ErrorResponseData("系统正忙，请稍后再试"), errors.New("Business Error")
+	}
+	redisCache.RemoveUserApply(uid, friendUid)
+	redisCache.RemoveUserRecommend(uid, friendUid)
+	return resp.SuccessResponseData(nil), nil
+}
+
+// ExpireApplyJob 周期性地将超时未处理的好友申请标记为过期
+func ExpireApplyJob() {
+	ticker := time.NewTicker(domainEnum.ApplyExpireInterval)
+	defer ticker.Stop()
+	for range ticker.C {
+		expireApply()
+	}
+}
+
+func expireApply() {
+	// 多实例部署时只需要一个实例执行
+	mutex := global.RedSync.NewMutex(domainEnum.ApplyExpireLock, redsync.WithExpiry(10*time.Minute))
+	if err := mutex.TryLock(); err != nil {
+		return
+	}
+	defer mutex.Unlock()
+
+	ctx := context.Background()
+	userApply := global.Query.UserApply
+	userApplyQ := userApply.WithContext(ctx)
+	deadline := time.Now().Add(-getApplyExpire())
+	for {
+		applyList, err := userApplyQ.Where(userApply.Status.Eq(domainEnum.ApplyWaiting), userApply.CreateTime.Lt(deadline)).Limit(domainEnum.ApplyExpireBatchSize).Find()
+		if err != nil {
+			global.Logger.Errorf("查询过期好友申请失败 %s", err)
+			return
+		}
+		if len(applyList) == 0 {
+			return
+		}
+		ids := make([]int64, 0, len(applyList))
+		for _, apply := range applyList {
+			ids = append(ids, apply.ID)
+		}
+		// 带上状态条件，避免覆盖刚被处理的申请
+		if _, err := userApplyQ.Where(userApply.ID.In(ids...), userApply.Status.Eq(domainEnum.ApplyWaiting)).Update(userApply.Status, domainEnum.ApplyExpired); err != nil {
+			global.Logger.Errorf("更新过期好友申请失败 %s", err)
+			return
+		}
+		removeApplyCache(applyList)
+		if len(applyList) < domainEnum.ApplyExpireBatchSize {
+			return
+		}
+	}
+}
+
+// removeApplyCache 移除好友申请缓存
+func removeApplyCache(applyList []*model.UserApply) {
+	for _, apply := range applyList {
+		redisCache.RemoveUserApply(apply.UID, apply.TargetID)
+	}
+}
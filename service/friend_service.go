@@ -12,15 +12,18 @@ import (
 	pkgReq "DiTing-Go/pkg/domain/vo/req"
 	"DiTing-Go/pkg/domain/vo/resp"
 	"DiTing-Go/pkg/utils"
-	"DiTing-Go/utils/jsonUtils"
+	"DiTing-Go/utils/outbox"
 	"DiTing-Go/utils/redisCache"
 	"context"
 	"fmt"
 	"github.com/pkg/errors"
 	"gorm.io/gen"
 	"gorm.io/gorm"
+	"math"
+	"slices"
 	"sort"
 	"strconv"
+	"strings"
 	"time"
 )
 
@@ -59,6 +62,15 @@ func ApplyFriendService(uid int64, applyReq req.UserApplyReq) (resp.ResponseData
 		return resp.ErrorResponseData("2系统正忙，请稍后再试"), errors.New("Business Error")
 	}
 
+	// 存在拉黑关系时不能发送好友申请
+	isBlocked, err := IsBlocked(uid, friendUid)
+	if err != nil {
+		return resp.ErrorResponseData("3系统正忙，请稍后再试"), errors.New("Business Error")
+	}
+	if isBlocked {
+		return resp.ErrorResponseData("无法添加对方为好友"), errors.New("Business Error")
+	}
+
 	// 检查是否已经是好友
 	isFriend, err := IsFriend(uid, friendUid)
 	if err != nil {
@@ -72,27 +84,37 @@ func ApplyFriendService(uid int64, applyReq req.UserApplyReq) (resp.ResponseData
 	// 检查是否已经发送过好友请求
 	userApply := global.Query.UserApply
 	userApplyQ := userApply.WithContext(ctx)
-	userApplyR := model.UserApply{}
+	oldApplyR := model.UserApply{}
 	fun = func() (interface{}, error) {
 		return userApplyQ.Where(userApply.UID.Eq(uid), userApply.TargetID.Eq(friendUid)).First()
 	}
 	key = fmt.Sprintf(domainEnum.UserApplyCacheByUidAndFriendUid, uid, friendUid)
-	err = utils.GetData(key, &userApplyR, fun)
-	if err == nil {
-		return resp.ErrorResponseData("已发送过好友请求，请等待对方同意"), errors.New("Business Error")
-	}
-	if !errors.Is(err, gorm.ErrRecordNotFound) {
+	err = utils.GetData(key, &oldApplyR, fun)
+	if err != nil && !errors.Is(err, gorm.ErrRecordNotFound) {
 		global.Logger.Errorf("查询好友请求失败 %s", err)
 		return resp.ErrorResponseData("4系统正忙，请稍后再试"), errors.New("Business Error")
 	}
+	hasOldApply := err == nil
+	if hasOldApply {
+		switch oldApplyR.Status {
+		case domainEnum.ApplyWaiting:
+			return resp.ErrorResponseData("已发送过好友请求，请等待对方同意"), errors.New("Business Error")
+		case domainEnum.ApplyRejected, domainEnum.ApplyExpired:
+			// 被拒绝或过期后需要冷却一段时间才能再次申请
+			if wait := time.Until(oldApplyR.UpdateTime.Add(getApplyCooldown())); wait > 0 {
+				return resp.ErrorResponseData(fmt.Sprintf("请%d小时后再试", int64(math.Ceil(wait.Hours())))), errors.New("Business Error")
+			}
+		}
+	}
 
-	// 检查对方是否已发送好友请求，如果是，直接同意
+	// 检查对方是否已发送好友请求且待审批，如果是，直接同意
+	userApplyR := model.UserApply{}
 	fun = func() (interface{}, error) {
 		return userApplyQ.Where(userApply.UID.Eq(friendUid), userApply.TargetID.Eq(uid)).First()
 	}
 	key = fmt.Sprintf(domainEnum.UserApplyCacheByUidAndFriendUid, friendUid, uid)
 	err = utils.GetData(key, &userApplyR, fun)
-	if err == nil {
+	if err == nil && userApplyR.Status == domainEnum.ApplyWaiting {
 		err := AgreeFriend(uid, friendUid)
 		if err != nil {
 			global.Logger.Errorf("同意好友请求失败 %s", err)
@@ -101,35 +123,57 @@ func ApplyFriendService(uid int64, applyReq req.UserApplyReq) (resp.ResponseData
 
 		return resp.SuccessResponseData(nil), nil
 	}
-	if !errors.Is(err, gorm.ErrRecordNotFound) {
+	if err != nil && !errors.Is(err, gorm.ErrRecordNotFound) {
 		global.Logger.Errorf("查询好友请求失败 %s", err)
 		return resp.ErrorResponseData("6系统正忙，请稍后再试"), errors.New("Business Error")
 	}
 
 	// 发送好友请求
-	err = userApplyQ.Create(&model.UserApply{
+	newUserApply := model.UserApply{
 		UID:        uid,
+		Type:       domainEnum.ApplyTypeFriend,
 		TargetID:   friendUid,
 		Msg:        applyReq.Msg,
-		Status:     enum.NO,
+		Status:     domainEnum.ApplyWaiting,
 		ReadStatus: enum.NO,
-	})
+		CreateTime: time.Now(),
+	}
+	tx := global.Query.Begin()
+	userApplyTx := tx.UserApply.WithContext(ctx)
+	if hasOldApply {
+		// 唯一索引限制每对用户只有一条申请，再次申请时复用旧记录
+		newUserApply.ID = oldApplyR.ID
+		_, err = userApplyTx.Where(userApply.ID.Eq(oldApplyR.ID)).UpdateSimple(
+			userApply.Msg.Value(newUserApply.Msg),
+			userApply.Status.Value(newUserApply.Status),
+			userApply.ReadStatus.Value(newUserApply.ReadStatus),
+			userApply.CreateTime.Value(newUserApply.CreateTime),
+		)
+	} else {
+		err = userApplyTx.Create(&newUserApply)
+	}
 	if err != nil {
+		if err := tx.Rollback(); err != nil {
+			global.Logger.Errorf("事务回滚失败 %s", err.Error())
+		}
 		global.Logger.Errorf("插入好友请求失败 %s", err)
 		return resp.ErrorResponseData("7系统正忙，请稍后再试"), errors.New("Business Error")
 	}
 
 	// 发送好友申请事件
-	err = jsonUtils.SendMsgSync(domainEnum.FriendApplyTopic, model.UserApply{
-		UID:        uid,
-		TargetID:   friendUid,
-		Msg:        applyReq.Msg,
-		Status:     enum.NO,
-		ReadStatus: enum.NO,
-	})
-	if err != nil {
+	if err := outbox.Save(tx, domainEnum.FriendApplyTopic, newUserApply); err != nil {
+		if err := tx.Rollback(); err != nil {
+			global.Logger.Errorf("事务回滚失败 %s", err.Error())
+		}
+		global.Logger.Errorf("写入好友申请事件失败 %s", err)
 		return resp.ErrorResponseData("8系统正忙，请稍后再试"), errors.New("Business Error")
 	}
+	if err := tx.Commit(); err != nil {
+		global.Logger.Errorf("事务提交失败 %s", err.Error())
+		return resp.ErrorResponseData("7系统正忙，请稍后再试"), errors.New("Business Error")
+	}
+	redisCache.RemoveUserApply(uid, friendUid)
+	redisCache.RemoveUserRecommend(uid, friendUid)
 
 	// 返回成功响应
 	return resp.SuccessResponseData(nil), nil
@@ -272,17 +316,15 @@ func AgreeFriend(uid, friendUid int64) error {
 			return err
 		}
 	}
-	if err := tx.Commit(); err != nil {
-		return err
-	}
-
 	// 发送新好友事件
-	err = jsonUtils.SendMsgSync(domainEnum.NewFriendTopic, userFriends[0])
-	if err != nil {
+	if err := outbox.Save(tx, domainEnum.NewFriendTopic, userFriends[0]); err != nil {
 		if err := tx.Rollback(); err != nil {
 			global.Logger.Errorf("事务回滚失败 %s", err.Error())
 		}
-		global.Logger.Errorf("发送新好友事件失败 %s", err.Error())
+		global.Logger.Errorf("写入新好友事件失败 %s", err.Error())
+		return err
+	}
+	if err := tx.Commit(); err != nil {
 		return err
 	}
 	return nil
@@ -381,12 +423,11 @@ func DeleteFriendService(uid int64, deleteFriendReq req.DeleteFriendReq) (resp.R
 		Uid:       uid,
 		FriendUid: deleteFriendUid,
 	}
-	err = jsonUtils.SendMsgSync(domainEnum.DeleteFriendTopic, DeleteFriendDto)
-	if err != nil {
+	if err := outbox.Save(tx, domainEnum.DeleteFriendTopic, DeleteFriendDto); err != nil {
 		if err := tx.Rollback(); err != nil {
 			global.Logger.Errorf("事务回滚失败 %s", err.Error())
 		}
-		global.Logger.Errorf("发送删除好友事件失败 %s", err.Error())
+		global.Logger.Errorf("写入删除好友事件失败 %s", err.Error())
 		return resp.ErrorResponseData("系统正忙，请稍后再试"), errors.New("Business Error")
 	}
 
@@ -456,11 +497,18 @@ func GetUserApplyService(uid int64, pageReq pkgReq.PageReq) (resp.ResponseData,
 
 	userApply := global.Query.UserApply
 	userApplyQ := global.Query.UserApply.WithContext(ctx)
-	// 更新已读状态
-	_, err = userApplyQ.Where(userApply.TargetID.Eq(uid), userApply.ReadStatus.Eq(enum.NO)).Update(userApply.ReadStatus, enum.YES)
-	if err != nil {
-		global.Logger.Errorf("更新好友申请表失败 %s", err)
-		return resp.ErrorResponseData("系统正忙，请稍后再试"), errors.New("Business Error")
+	// 本页展示过的申请标记为已读
+	applyIds := make([]int64, 0, len(userApplys))
+	for _, apply := range userApplys {
+		if apply.ReadStatus == enum.NO {
+			applyIds = append(applyIds, apply.ID)
+		}
+	}
+	if len(applyIds) > 0 {
+		if _, err := userApplyQ.Where(userApply.ID.In(applyIds...), userApply.TargetID.Eq(uid)).Update(userApply.ReadStatus, enum.YES); err != nil {
+			global.Logger.Errorf("更新好友申请表失败 %s", err)
+			return resp.ErrorResponseData("系统正忙，请稍后再试"), errors.New("Business Error")
+		}
 	}
 
 	return resp.SuccessResponseData(pageResp), nil
@@ -483,7 +531,8 @@ func UnreadApplyNumService(uid int64) (resp.ResponseData, error) {
 }
 
 // GetFriendListService 获取好友列表
-func GetFriendListService(uid int64, pageReq pkgReq.PageReq) (resp.ResponseData, error) {
+func GetFriendListService(uid int64, friendListReq req.GetFriendListReq) (resp.ResponseData, error) {
+	pageReq := friendListReq.PageReq
 	if pageReq.Cursor != nil && *pageReq.Cursor != "" {
 		// 时间戳转时间
 		timestamp, err := strconv.ParseInt(*pageReq.Cursor, 10, 64)
@@ -501,15 +550,21 @@ func GetFriendListService(uid int64, pageReq pkgReq.PageReq) (resp.ResponseData,
 	db := dal.DB
 	userFriend := make([]model.UserFriend, 0)
 	condition := []interface{}{"uid=? and delete_status=?", strconv.FormatInt(uid, 10), enum.NORMAL}
+	// 按标签筛选
+	if friendListReq.Tag != "" {
+		condition[0] = condition[0].(string) + " and FIND_IN_SET(?, tags) > 0"
+		condition = append(condition, friendListReq.Tag)
+	}
 	pageResp, err := utils.Paginate(db, pageReq, &userFriend, "create_time", false, condition...)
 	if err != nil {
 		global.Logger.Errorf("分页查询失败 %v", err)
 		return resp.ErrorResponseData("系统繁忙，请稍后再试"), errors.New("Business Error")
 	}
 	uids := make([]int64, 0)
-
+	friendMap := make(map[int64]model.UserFriend)
 	for _, friend := range userFriend {
 		uids = append(uids, friend.FriendUID)
+		friendMap[friend.FriendUID] = friend
 	}
 
 	// 获取好友信息
@@ -528,9 +583,92 @@ func GetFriendListService(uid int64, pageReq pkgReq.PageReq) (resp.ResponseData,
 			Uid:          friend.ID,
 			LastOptTime:  friend.LastOptTime.UnixMilli(),
 			ActiveStatus: int(friend.ActiveStatus),
+			Remark:       friendMap[friend.ID].Remark,
+			Tags:         splitFriendTags(friendMap[friend.ID].Tags),
 		}
 		friendListVO = append(friendListVO, friendResp)
 	}
 	pageResp.Data = friendListVO
 	return resp.SuccessResponseData(pageResp), nil
 }
+
+// splitFriendTags 拆分好友标签
+func splitFriendTags(tags string) []string {
+	if tags == "" {
+		return []string{}
+	}
+	return strings.Split(tags, domainEnum.FriendTagSeparator)
+}
+
+// UpdateFriendRemarkService 修改好友备注和标签
+func UpdateFriendRemarkService(uid int64, remarkReq req.UpdateFriendRemarkReq) (resp.ResponseData, error) {
+	ctx := context.Background()
+	isFriend, err := IsFriend(uid, remarkReq.FriendUid)
+	if err != nil {
+		global.Logger.Errorf("查询好友失败 %s", err)
+		return resp.ErrorResponseData("系统正忙，请稍后再试"), errors.New("Business Error")
+	}
+	if !isFriend {
+		return resp.ErrorResponseData("对方不是你的好友"), errors.New("Business Error")
+	}
+
+	// 标签去重并保持顺序
+	tags := make([]string, 0, len(remarkReq.Tags))
+	for _, tag := range remarkReq.Tags {
+		tag = strings.TrimSpace(tag)
+		if tag != "" && !slices.Contains(tags, tag) {
+			tags = append(tags, tag)
+		}
+	}
+
+	userFriend := global.Query.UserFriend
+	userFriendQ := userFriend.WithContext(ctx)
+	if _, err := userFriendQ.Where(userFriend.UID.Eq(uid), userFriend.FriendUID.Eq(remarkReq.FriendUid)).UpdateSimple(
+		userFriend.Remark.Value(strings.TrimSpace(remarkReq.Remark)),
+		userFriend.Tags.Value(strings.Join(tags, domainEnum.FriendTagSeparator)),
+	); err != nil {
+		global.Logger.Errorf("更新好友备注失败 %s", err)
+		return resp.ErrorResponseData("系统正忙，请稍后再试"), errors.New("Business Error")
+	}
+	redisCache.RemoveUserFriend(uid, remarkReq.FriendUid)
+	return resp.SuccessResponseDataWithMsg("修改成功"), nil
+}
+
+// GetFriendTagsService 获取用户使用过的好友标签，用于好友分组
+func GetFriendTagsService(uid int64) (resp.ResponseData, error) {
+	userFriend := global.Query.UserFriend
+	userFriendQ := userFriend.WithContext(context.Background())
+	tagsList := make([]string, 0)
+	if err := userFriendQ.Where(userFriend.UID.Eq(uid), userFriend.DeleteStatus.Eq(enum.NORMAL), userFriend.Tags.Neq("")).Distinct().Pluck(userFriend.Tags, &tagsList); err != nil {
+		global.Logger.Errorf("查询好友标签失败 %s", err)
+		return resp.ErrorResponseData("系统正忙，请稍后再试"), errors.New("Business Error")
+	}
+	result := make([]string, 0)
+	for _, tags := range tagsList {
+		for _, tag := range splitFriendTags(tags) {
+			if !slices.Contains(result, tag) {
+				result = append(result, tag)
+			}
+		}
+	}
+	return resp.SuccessResponseData(result), nil
+}
+
+// getFriendRemarkMap 查询好友备注，key为好友uid
+func getFriendRemarkMap(uid int64, friendUids []int64) (map[int64]string, error) {
+	remarkMap := make(map[int64]string)
+	if len(friendUids) == 0 {
+		return remarkMap, nil
+	}
+	userFriend := global.Query.UserFriend
+	userFriendQ := userFriend.WithContext(context.Background())
+	friendList, err := userFriendQ.Select(userFriend.FriendUID, userFriend.Remark).Where(userFriend.UID.Eq(uid), userFriend.FriendUID.In(friendUids...), userFriend.Remark.Neq("")).Find()
+	if err != nil {
+		global.Logger.Errorf("查询好友备注失败 %s", err)
+		return nil, err
+	}
+	for _, friend := range friendList {
+		remarkMap[friend.FriendUID] = friend.Remark
+	}
+	return remarkMap, nil
+}
@@ -9,6 +9,7 @@ import (
 	pkgEnum "DiTing-Go/pkg/domain/enum"
 	"DiTing-Go/pkg/domain/vo/resp"
 	pkgResp "DiTing-Go/pkg/domain/vo/resp"
+	"DiTing-Go/utils/outbox"
 	"context"
 	"fmt"
 	"github.com/gin-gonic/gin"
@@ -138,13 +139,19 @@ func CreateGroupService(uid int64, uidList []int64) (pkgResp.ResponseData, error
 		return pkgResp.ErrorResponseData("系统繁忙，请稍后再试~"), errors.New("Business Error")
 	}
 
+	// 发送新消息事件
+	if err := outbox.Save(tx, enum.NewMessageTopic, newMessage); err != nil {
+		if err := tx.Rollback(); err != nil {
+			global.Logger.Errorf("事务回滚失败 %s", err.Error())
+		}
+		global.Logger.Errorf("写入新消息事件失败 %s", err.Error())
+		return pkgResp.ErrorResponseData("系统繁忙，请稍后再试~"), errors.New("Business Error")
+	}
 	if err := tx.Commit(); err != nil {
 		global.Logger.Errorf("事务提交失败 %s", err.Error())
 		return pkgResp.ErrorResponseData("系统繁忙，请稍后再试~"), errors.New("Business Error")
 	}
 
-	global.Bus.Publish(enum.NewMessageEvent, newMessage)
-
 	return pkgResp.SuccessResponseData("success"), nil
 }
 
@@ -409,13 +416,23 @@ func JoinGroupService(c *gin.Context) {
 		global.Logger.Errorf("添加消息表失败 %s", err.Error())
 		return
 	}
+	// 发送新消息事件
+	if err := outbox.Save(tx, enum.NewMessageTopic, newMessage); err != nil {
+		if err := tx.Rollback(); err != nil {
+			global.Logger.Errorf("事务回滚失败 %s", err.Error())
+			return
+		}
+		resp.ErrorResponse(c, "加入群聊失败")
+		c.Abort()
+		global.Logger.Errorf("写入新消息事件失败 %s", err.Error())
+		return
+	}
 	if err := tx.Commit(); err != nil {
 		global.Logger.Errorf("事务提交失败 %s", err.Error())
 		resp.ErrorResponse(c, "加入群聊失败")
 		c.Abort()
 		return
 	}
-	global.Bus.Publish(enum.NewMessageEvent, newMessage)
 
 	resp.SuccessResponseWithMsg(c, "success")
 }
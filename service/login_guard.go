@@ -0,0 +1,132 @@
+package service
+
+import (
+	"DiTing-Go/domain/dto"
+	domainEnum "DiTing-Go/domain/enum"
+	"DiTing-Go/global"
+	"DiTing-Go/utils/jsonUtils"
+	"fmt"
+	"github.com/spf13/viper"
+	"strings"
+	"time"
+)
+
+// loginLimit 一个维度的登录失败限制
+type loginLimit struct {
+	failKey string
+	lockKey string
+	max     int64
+}
+
+// getLoginLimits 按用户名和ip两个维度限制，配置项 login.maxFailures、login.ipMaxFailures
+func getLoginLimits(username, ip string) []loginLimit {
+	maxFailures := viper.GetInt64("login.maxFailures")
+	if maxFailures <= 0 {
+		maxFailures = domainEnum.DefaultLoginMaxFailures
+	}
+	ipMaxFailures := viper.GetInt64("login.ipMaxFailures")
+	if ipMaxFailures <= 0 {
+		ipMaxFailures = domainEnum.DefaultLoginIpMaxFailures
+	}
+	// 用户名不区分大小写统计，避免换大小写绕过
+	name := strings.ToLower(username)
+	return []loginLimit{
+		{failKey: fmt.Sprintf(domainEnum.LoginFailByName, name), lockKey: fmt.Sprintf(domainEnum.LoginLockByName, name), max: maxFailures},
+		{failKey: fmt.Sprintf(domainEnum.LoginFailByIp, ip), lockKey: fmt.Sprintf(domainEnum.LoginLockByIp, ip), max: ipMaxFailures},
+	}
+}
+
+// getLoginFailWindow 失败次数统计窗口，配置项 login.failWindow
+func getLoginFailWindow() time.Duration {
+	if window := viper.GetDuration("login.failWindow"); window > 0 {
+		return window
+	}
+	return domainEnum.DefaultLoginFailWindow
+}
+
+// getLoginLockDuration 超出阈值后的锁定时长，每多失败一次翻倍，配置项 login.lockBase、login.lockMax
+func getLoginLockDuration(over int64) time.Duration {
+	base := viper.GetDuration("login.lockBase")
+	if base <= 0 {
+		base = domainEnum.DefaultLoginLockBase
+	}
+	maxLock := viper.GetDuration("login.lockMax")
+	if maxLock <= 0 {
+		maxLock = domainEnum.DefaultLoginLockMax
+	}
+	lock := base
+	for i := int64(0); i < over && lock < maxLock; i++ {
+		lock *= 2
+	}
+	return min(lock, maxLock)
+}
+
+// checkLoginLocked 返回剩余锁定时长，未锁定时返回0
+func checkLoginLocked(username, ip string) time.Duration {
+	var locked time.Duration
+	for _, limit := range getLoginLimits(username, ip) {
+		ttl, err := global.Rdb.TTL(limit.lockKey).Result()
+		if err != nil {
+			global.Logger.Errorf("查询登录锁定失败 %s", err)
+			continue
+		}
+		locked = max(locked, ttl)
+	}
+	return locked
+}
+
+// recordLoginFailure 记录一次登录失败，超过阈值后锁定，返回锁定时长
+func recordLoginFailure(username, ip string) time.Duration {
+	window := getLoginFailWindow()
+	var locked time.Duration
+	var failures int64
+	for _, limit := range getLoginLimits(username, ip) {
+		count, err := global.Rdb.Incr(limit.failKey).Result()
+		if err != nil {
+			global.Logger.Errorf("记录登录失败次数失败 %s", err)
+			continue
+		}
+		if count == 1 {
+			global.Rdb.Expire(limit.failKey, window)
+		}
+		failures = max(failures, count)
+		if count < limit.max {
+			continue
+		}
+		lock := getLoginLockDuration(count - limit.max)
+		if err := global.Rdb.Set(limit.lockKey, count, lock).Err(); err != nil {
+			global.Logger.Errorf("设置登录锁定失败 %s", err)
+			continue
+		}
+		// 锁定期间保留失败次数，解锁后再失败继续翻倍
+		global.Rdb.Expire(limit.failKey, max(window, lock+window))
+		locked = max(locked, lock)
+	}
+
+	global.Logger.Warnf("登录失败 username:%s ip:%s failures:%d", username, ip, failures)
+	if locked > 0 {
+		publishSecurityEvent(dto.SecurityEventDto{
+			Type:        domainEnum.SecurityLoginLocked,
+			Username:    username,
+			Ip:          ip,
+			Failures:    failures,
+			LockSeconds: int64(locked / time.Second),
+			Time:        time.Now(),
+		})
+	}
+	return locked
+}
+
+// clearLoginFailure 登录成功后清除用户名维度的失败记录，ip维度保留，防止用自己的账号重置计数
+func clearLoginFailure(username, ip string) {
+	limit := getLoginLimits(username, ip)[0]
+	global.Rdb.Del(limit.failKey, limit.lockKey)
+}
+
+// publishSecurityEvent 发送安全事件
+func publishSecurityEvent(event dto.SecurityEventDto) {
+	global.Logger.Warnf("安全事件 type:%s username:%s ip:%s failures:%d lock:%ds", event.Type, event.Username, event.Ip, event.Failures, event.LockSeconds)
+	if err := jsonUtils.SendMsgSync(domainEnum.SecurityEventTopic, event); err != nil {
+		global.Logger.Errorf("发送安全事件失败 %s", err)
+	}
+}
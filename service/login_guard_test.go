@@ -0,0 +1,35 @@
+//go:build integration
+
+package service
+
+import (
+	"github.com/spf13/viper"
+	"testing"
+	"time"
+)
+
+func TestGetLoginLockDuration(t *testing.T) {
+	base, maxLock := viper.Get("login.lockBase"), viper.Get("login.lockMax")
+	t.Cleanup(func() {
+		viper.Set("login.lockBase", base)
+		viper.Set("login.lockMax", maxLock)
+	})
+	viper.Set("login.lockBase", time.Minute)
+	viper.Set("login.lockMax", 10*time.Minute)
+
+	tests := []struct {
+		over int64
+		want time.Duration
+	}{
+		{over: 0, want: time.Minute},
+		{over: 1, want: 2 * time.Minute},
+		{over: 3, want: 8 * time.Minute},
+		{over: 4, want: 10 * time.Minute},
+		{over: 100, want: 10 * time.Minute},
+	}
+	for _, tt := range tests {
+		if got := getLoginLockDuration(tt.over); got != tt.want {
+			t.Errorf("getLoginLockDuration(%d) = %v, want %v", tt.over, got, tt.want)
+		}
+	}
+}
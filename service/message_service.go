@@ -2,16 +2,21 @@ package service
 
 import (
 	"DiTing-Go/dal/model"
+	"DiTing-Go/dal/query"
 	"DiTing-Go/domain/enum"
 	"DiTing-Go/domain/vo/req"
 	domainResp "DiTing-Go/domain/vo/resp"
 	"DiTing-Go/global"
 	pkgEnum "DiTing-Go/pkg/domain/enum"
 	"DiTing-Go/pkg/domain/vo/resp"
+	"DiTing-Go/utils/outbox"
 	"context"
-	"github.com/apache/rocketmq-client-go/v2/primitive"
-	"github.com/goccy/go-json"
+	"encoding/json"
+	"github.com/pkg/errors"
+	"github.com/spf13/viper"
+	"gorm.io/gorm"
 	"log"
+	"slices"
 	"time"
 )
 
@@ -26,30 +31,80 @@ func SendTextMsgService(uid int64, msgReq req.MessageReq) (resp.ResponseData, er
 		return resp.ErrorResponseData("消息发送失败"), err
 	}
 
+	// 计划时间在未来的消息保存为定时消息
+	if msgReq.SendAt > 0 && time.UnixMilli(msgReq.SendAt).After(time.Now().Add(enum.ScheduledMsgMinAhead)) {
+		return ScheduleMsgService(uid, msgReq)
+	}
+
+	// 重试请求直接返回已经保存的消息
+	if msgReq.ClientMsgId != "" {
+		existMsg, err := getMessageByClientMsgId(uid, msgReq.ClientMsgId)
+		if err != nil {
+			return resp.ErrorResponseData("消息发送失败"), err
+		}
+		if existMsg != nil {
+			// 同一客户端消息ID只能对应一个房间
+			if existMsg.RoomID != msgReq.RoomId {
+				return resp.ErrorResponseData("客户端消息ID重复"), errors.New("Business Error")
+			}
+			return resp.SuccessResponseData(buildMessageResp(userR, existMsg)), nil
+		}
+	}
+
+	// 单聊存在拉黑关系时拒绝发送
+	isBlocked, err := IsRoomBlocked(uid, msgReq.RoomId)
+	if err != nil {
+		return resp.ErrorResponseData("消息发送失败"), err
+	}
+	if isBlocked {
+		return resp.ErrorResponseDataWithCode(pkgEnum.ERROR_USER_BLOCKED, nil), errors.New("Business Error")
+	}
+
 	msg := model.Message{}
 	msg.Type = msgReq.MsgType
 	msg.FromUID = uid
 	msg.RoomID = msgReq.RoomId
 	msg.Content = msgReq.Body.Content
+	msg.ReplyMsgID = msgReq.Body.ReplyMsgId
+	msg.ClientMsgID = msgReq.ClientMsgId
 	if msg.Extra == "" {
 		msg.Extra = "{}"
 	}
 
 	// 发送消息
 	if err := SendTextMsg(&msg); err != nil {
+		// 并发重试时唯一索引冲突，返回先保存的消息
+		if msgReq.ClientMsgId != "" {
+			existMsg, _ := getMessageByClientMsgId(uid, msgReq.ClientMsgId)
+			if existMsg != nil && existMsg.RoomID == msgReq.RoomId {
+				return resp.SuccessResponseData(buildMessageResp(userR, existMsg)), nil
+			}
+		}
 		return resp.ErrorResponseData("消息发送失败"), err
 	}
-	// 发送新消息事件
-	newMsgByte, _ := json.Marshal(msg)
-	rMsg := &primitive.Message{
-		Topic: enum.NewMessageTopic,
-		Body:  newMsgByte,
+	// 返回成功
+	return resp.SuccessResponseData(buildMessageResp(userR, &msg)), nil
+}
+
+// getMessageByClientMsgId 根据客户端消息ID查询已发送的消息，不存在时返回nil
+func getMessageByClientMsgId(uid int64, clientMsgId string) (*model.Message, error) {
+	message := global.Query.Message
+	messageQ := message.WithContext(context.Background())
+	msg, err := messageQ.Where(message.FromUID.Eq(uid), message.ClientMsgID.Eq(clientMsgId)).First()
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, nil
+		}
+		global.Logger.Errorf("查询消息失败 %s", err)
+		return nil, err
 	}
-	_, _ = global.RocketProducer.SendSync(ctx, rMsg)
+	return msg, nil
+}
 
+func buildMessageResp(userR *model.User, msg *model.Message) domainResp.MessageResp {
 	msgResp := domainResp.MessageResp{
 		FromUser: domainResp.MsgUser{
-			Uid:      uid,
+			Uid:      userR.ID,
 			Username: userR.Name,
 			Avatar:   userR.Avatar,
 		},
@@ -62,21 +117,157 @@ func SendTextMsgService(uid int64, msgReq req.MessageReq) (resp.ResponseData, er
 				Content: msg.Content,
 				Reply:   msg.ReplyMsgID,
 			},
+			ClientMsgId: msg.ClientMsgID,
+			Forwarded:   msg.Forwarded == pkgEnum.YES,
+			Edited:      !msg.EditTime.IsZero(),
+			EditTime:    timeMilli(msg.EditTime),
+			ExpireTime:  timeMilli(msg.ExpireAt),
 		},
 	}
+	if slices.Contains(enum.ExtraMessageTypes, msg.Type) {
+		msgResp.Message.Body.Extra = json.RawMessage(msg.Extra)
+	}
+	return msgResp
+}
 
-	// 返回成功
-	return resp.SuccessResponseData(msgResp), nil
+// timeMilli 可为空的时间转毫秒时间戳，为空返回0
+func timeMilli(t time.Time) int64 {
+	if t.IsZero() {
+		return 0
+	}
+	return t.UnixMilli()
 }
 
+// SendTextMsg 保存消息，并在同一事务中写入新消息事件
 func SendTextMsg(msg *model.Message) error {
+	tx := global.Query.Begin()
+	if err := SendTextMsgTx(tx, msg); err != nil {
+		if err := tx.Rollback(); err != nil {
+			global.Logger.Errorf("事务回滚失败 %s", err.Error())
+		}
+		return err
+	}
+	if err := tx.Commit(); err != nil {
+		global.Logger.Errorf("事务提交失败 %s", err.Error())
+		return err
+	}
+	return nil
+}
+
+// SendTextMsgTx 在调用方的事务中保存消息和新消息事件，由调用方负责提交或回滚
+func SendTextMsgTx(tx *query.QueryTx, msg *model.Message) error {
 	msg.CreateTime = time.Now()
 	msg.DeleteStatus = pkgEnum.NORMAL
+	if err := stampMsgExpire(tx, msg); err != nil {
+		return err
+	}
 	ctx := context.Background()
-	msgQ := global.Query.WithContext(ctx).Message
-	if err := msgQ.Create(msg); err != nil {
+	msgTx := tx.WithContext(ctx).Message
+	if err := msgTx.Create(msg); err != nil {
 		log.Println("消息发送失败", err.Error())
 		return err
 	}
+	// 转发的图片和聊天记录引用原文件
+	if err := saveObjectRefs(tx, enum.ObjectRefMessage, msg.ID, msgObjectNames(msg.Type, msg.Extra)); err != nil {
+		return err
+	}
+	// 发送新消息事件
+	if err := outbox.Save(tx, enum.NewMessageTopic, msg); err != nil {
+		global.Logger.Errorf("写入新消息事件失败 %s", err.Error())
+		return err
+	}
 	return nil
 }
+
+// getMsgEditWindow 消息允许编辑的时长，配置项 chat.editWindow
+func getMsgEditWindow() time.Duration {
+	if window := viper.GetDuration("chat.editWindow"); window > 0 {
+		return window
+	}
+	return enum.DefaultMsgEditWindow
+}
+
+// EditMsgService 编辑文本消息，编辑前的内容写入编辑历史
+func EditMsgService(uid int64, editReq req.EditMessageReq) (resp.ResponseData, error) {
+	ctx := context.Background()
+	message := global.Query.Message
+	messageQ := message.WithContext(ctx)
+	msg, err := messageQ.Where(message.ID.Eq(editReq.MsgId)).First()
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return resp.ErrorResponseData("消息不存在"), errors.New("Business Error")
+		}
+		global.Logger.Errorf("查询消息失败 %s", err)
+		return resp.ErrorResponseData("系统繁忙，请稍后再试~"), errors.New("Business Error")
+	}
+	if msg.FromUID != uid || msg.DeleteStatus != pkgEnum.NORMAL {
+		return resp.ErrorResponseData("消息不存在"), errors.New("Business Error")
+	}
+	if msg.Type != enum.TextMessageType {
+		return resp.ErrorResponseData("只能编辑文本消息"), errors.New("Business Error")
+	}
+	if msg.Forwarded == pkgEnum.YES {
+		return resp.ErrorResponseData("转发的消息不能编辑"), errors.New("Business Error")
+	}
+	if time.Since(msg.CreateTime) > getMsgEditWindow() {
+		return resp.ErrorResponseData("消息发送时间过久，无法编辑"), errors.New("Business Error")
+	}
+	// 单聊存在拉黑关系时不允许编辑
+	isBlocked, err := IsRoomBlocked(uid, msg.RoomID)
+	if err != nil {
+		return resp.ErrorResponseData("系统繁忙，请稍后再试~"), errors.New("Business Error")
+	}
+	if isBlocked {
+		return resp.ErrorResponseDataWithCode(pkgEnum.ERROR_USER_BLOCKED, nil), errors.New("Business Error")
+	}
+
+	user := global.Query.User
+	userR, err := user.WithContext(ctx).Where(user.ID.Eq(uid)).First()
+	if err != nil {
+		global.Logger.Errorf("查询用户失败 %s", err)
+		return resp.ErrorResponseData("系统繁忙，请稍后再试~"), errors.New("Business Error")
+	}
+	if msg.Content == editReq.Content {
+		return resp.SuccessResponseData(buildMessageResp(userR, msg)), nil
+	}
+
+	tx := global.Query.Begin()
+	if err := tx.MessageEditHistory.WithContext(ctx).Create(&model.MessageEditHistory{
+		MsgID:   msg.ID,
+		Content: msg.Content,
+	}); err != nil {
+		if err := tx.Rollback(); err != nil {
+			global.Logger.Errorf("事务回滚失败 %s", err.Error())
+		}
+		global.Logger.Errorf("写入消息编辑历史失败 %s", err)
+		return resp.ErrorResponseData("系统繁忙，请稍后再试~"), errors.New("Business Error")
+	}
+	// 内容作为更新条件，并发编辑时只有一个请求成功，历史不会丢失
+	editTime := time.Now()
+	result, err := tx.Message.WithContext(ctx).Where(message.ID.Eq(msg.ID), message.Content.Eq(msg.Content)).UpdateSimple(message.Content.Value(editReq.Content), message.EditTime.Value(editTime))
+	if err != nil || result.RowsAffected == 0 {
+		if err := tx.Rollback(); err != nil {
+			global.Logger.Errorf("事务回滚失败 %s", err.Error())
+		}
+		if err != nil {
+			global.Logger.Errorf("编辑消息失败 %s", err)
+			return resp.ErrorResponseData("系统繁忙，请稍后再试~"), errors.New("Business Error")
+		}
+		return resp.ErrorResponseData("消息已被修改，请刷新后重试"), errors.New("Business Error")
+	}
+	msg.Content = editReq.Content
+	msg.EditTime = editTime
+	// 发送消息编辑事件
+	if err := outbox.Save(tx, enum.MessageEditTopic, msg); err != nil {
+		if err := tx.Rollback(); err != nil {
+			global.Logger.Errorf("事务回滚失败 %s", err.Error())
+		}
+		global.Logger.Errorf("写入消息编辑事件失败 %s", err)
+		return resp.ErrorResponseData("系统繁忙，请稍后再试~"), errors.New("Business Error")
+	}
+	if err := tx.Commit(); err != nil {
+		global.Logger.Errorf("事务提交失败 %s", err.Error())
+		return resp.ErrorResponseData("系统繁忙，请稍后再试~"), errors.New("Business Error")
+	}
+	return resp.SuccessResponseData(buildMessageResp(userR, msg)), nil
+}
@@ -0,0 +1,136 @@
+package service
+
+import (
+	"DiTing-Go/dal/model"
+	"DiTing-Go/dal/query"
+	"DiTing-Go/domain/dto"
+	"DiTing-Go/domain/enum"
+	"DiTing-Go/global"
+	"context"
+	"github.com/go-redis/redis"
+	"github.com/goccy/go-json"
+	"github.com/minio/minio-go/v7"
+	"slices"
+	"strconv"
+	"time"
+)
+
+// msgObjectNames 消息引用的存储文件，图片消息为图片本身，聊天记录为其中的图片
+func msgObjectNames(msgType int32, extraStr string) []string {
+	names := make([]string, 0)
+	switch msgType {
+	case enum.ImgMessageType:
+		extra := dto.ImgMessageDto{}
+		if err := json.Unmarshal([]byte(extraStr), &extra); err != nil {
+			global.Logger.Errorf("json反序列化失败 %s", err)
+			return names
+		}
+		if extra.MessageBaseDto.Name != "" {
+			names = append(names, extra.MessageBaseDto.Name)
+		}
+	case enum.ChatHistoryMessageType:
+		chatHistory := dto.ChatHistoryDto{}
+		if err := json.Unmarshal([]byte(extraStr), &chatHistory); err != nil {
+			global.Logger.Errorf("json反序列化失败 %s", err)
+			return names
+		}
+		for _, item := range chatHistory.Messages {
+			names = append(names, msgObjectNames(item.Type, string(item.Extra))...)
+		}
+	}
+	slices.Sort(names)
+	return slices.Compact(names)
+}
+
+// saveObjectRefs 在事务中记录引用方对文件的引用
+func saveObjectRefs(tx *query.QueryTx, refType int32, refId int64, names []string) error {
+	if len(names) == 0 {
+		return nil
+	}
+	refs := make([]*model.ObjectRef, 0, len(names))
+	for _, name := range names {
+		refs = append(refs, &model.ObjectRef{
+			ObjectName: name,
+			RefType:    refType,
+			RefID:      refId,
+		})
+	}
+	if err := tx.ObjectRef.WithContext(context.Background()).Create(refs...); err != nil {
+		global.Logger.Errorf("保存文件引用失败 %s", err)
+		return err
+	}
+	return nil
+}
+
+// releaseObjectRefs 移除引用方对文件的引用，文件加入延迟清理队列
+// 转发或收藏可能在引用移除的同时新增引用，延迟后再检查引用数，避免删除仍被引用的文件
+func releaseObjectRefs(refType int32, refIds []int64) {
+	if len(refIds) == 0 {
+		return
+	}
+	ctx := context.Background()
+	objectRef := global.Query.ObjectRef
+	objectRefQ := objectRef.WithContext(ctx)
+	names := make([]string, 0)
+	if err := objectRefQ.Where(objectRef.RefType.Eq(refType), objectRef.RefID.In(refIds...)).Distinct(objectRef.ObjectName).Pluck(objectRef.ObjectName, &names); err != nil {
+		global.Logger.Errorf("查询文件引用失败 %s", err)
+		return
+	}
+	if len(names) == 0 {
+		return
+	}
+	if _, err := objectRefQ.Where(objectRef.RefType.Eq(refType), objectRef.RefID.In(refIds...)).Delete(); err != nil {
+		global.Logger.Errorf("删除文件引用失败 %s", err)
+		return
+	}
+	score := float64(time.Now().Add(enum.ObjectGcDelay).UnixMilli())
+	members := make([]redis.Z, 0, len(names))
+	for _, name := range names {
+		members = append(members, redis.Z{Score: score, Member: name})
+	}
+	if err := global.Rdb.ZAdd(enum.ObjectGcZSet, members...).Err(); err != nil {
+		global.Logger.Errorf("加入文件清理队列失败 %s", err)
+	}
+}
+
+// ObjectGcJob 周期性地删除延迟期满且不再被引用的文件
+func ObjectGcJob() {
+	ticker := time.NewTicker(enum.ObjectGcInterval)
+	defer ticker.Stop()
+	for range ticker.C {
+		gcObjects()
+	}
+}
+
+func gcObjects() {
+	names, err := global.Rdb.ZRangeByScore(enum.ObjectGcZSet, redis.ZRangeBy{
+		Min:   "-inf",
+		Max:   strconv.FormatInt(time.Now().UnixMilli(), 10),
+		Count: enum.ObjectGcBatchSize,
+	}).Result()
+	if err != nil {
+		global.Logger.Errorf("查询待清理的文件失败 %s", err)
+		return
+	}
+	ctx := context.Background()
+	objectRef := global.Query.ObjectRef
+	objectRefQ := objectRef.WithContext(ctx)
+	for _, name := range names {
+		// 多实例部署时只有移除成功的实例负责清理
+		removed, err := global.Rdb.ZRem(enum.ObjectGcZSet, name).Result()
+		if err != nil || removed == 0 {
+			continue
+		}
+		count, err := objectRefQ.Where(objectRef.ObjectName.Eq(name)).Count()
+		if err != nil {
+			global.Logger.Errorf("查询文件引用失败 %s", err)
+			continue
+		}
+		if count > 0 {
+			continue
+		}
+		if err := global.MinioClient.RemoveObject(ctx, enum.MinioBucket, name, minio.RemoveObjectOptions{}); err != nil {
+			global.Logger.Errorf("删除文件失败 %s", err)
+		}
+	}
+}
@@ -0,0 +1,66 @@
+//go:build integration
+
+package service
+
+import (
+	"DiTing-Go/domain/enum"
+	"slices"
+	"testing"
+)
+
+func TestMsgObjectNames(t *testing.T) {
+	tests := []struct {
+		name    string
+		msgType int32
+		extra   string
+		want    []string
+	}{
+		{
+			name:    "图片消息",
+			msgType: enum.ImgMessageType,
+			extra:   `{"message_base_dto":{"url":"/api/file/download?msgId=1","size":10,"name":"2024-01-01/1/a.png"},"height":-1,"width":-1}`,
+			want:    []string{"2024-01-01/1/a.png"},
+		},
+		{
+			name:    "图片消息没有文件名",
+			msgType: enum.ImgMessageType,
+			extra:   `{"message_base_dto":{"size":10}}`,
+			want:    []string{},
+		},
+		{
+			name:    "聊天记录中的图片去重排序",
+			msgType: enum.ChatHistoryMessageType,
+			extra: `{"room_id":1,"messages":[` +
+				`{"msg_id":1,"type":3,"extra":{"message_base_dto":{"name":"b.png"}}},` +
+				`{"msg_id":2,"type":1,"content":"hi","extra":{}},` +
+				`{"msg_id":3,"type":3,"extra":{"message_base_dto":{"name":"a.png"}}},` +
+				`{"msg_id":4,"type":3,"extra":{"message_base_dto":{"name":"b.png"}}}]}`,
+			want: []string{"a.png", "b.png"},
+		},
+		{
+			name:    "嵌套的聊天记录只有摘要",
+			msgType: enum.ChatHistoryMessageType,
+			extra:   `{"room_id":1,"messages":[{"msg_id":1,"type":4,"extra":{"room_id":2,"count":3}}]}`,
+			want:    []string{},
+		},
+		{
+			name:    "文本消息",
+			msgType: enum.TextMessageType,
+			extra:   `{}`,
+			want:    []string{},
+		},
+		{
+			name:    "扩展信息格式错误",
+			msgType: enum.ImgMessageType,
+			extra:   `not json`,
+			want:    []string{},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := msgObjectNames(tt.msgType, tt.extra); !slices.Equal(got, tt.want) {
+				t.Errorf("msgObjectNames() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
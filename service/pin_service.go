@@ -0,0 +1,259 @@
+package service
+
+import (
+	"DiTing-Go/dal/model"
+	"DiTing-Go/domain/dto"
+	"DiTing-Go/domain/enum"
+	"DiTing-Go/domain/vo/req"
+	domainResp "DiTing-Go/domain/vo/resp"
+	"DiTing-Go/global"
+	pkgEnum "DiTing-Go/pkg/domain/enum"
+	"DiTing-Go/pkg/domain/vo/resp"
+	"DiTing-Go/pkg/utils"
+	"DiTing-Go/service/adapter"
+	"DiTing-Go/utils/outbox"
+	"context"
+	"fmt"
+	"github.com/pkg/errors"
+	"github.com/spf13/viper"
+	"gorm.io/gorm"
+)
+
+// getRoomPinLimit 每个房间最多的置顶消息数，配置项 chat.pinLimit
+func getRoomPinLimit() int64 {
+	if limit := viper.GetInt64("chat.pinLimit"); limit > 0 {
+		return limit
+	}
+	return enum.DefaultRoomPinLimit
+}
+
+// PinMsgService 置顶消息
+func PinMsgService(uid int64, pinReq req.PinMsgReq) (resp.ResponseData, error) {
+	ctx := context.Background()
+	message := global.Query.Message
+	msg, err := message.WithContext(ctx).Where(message.ID.Eq(pinReq.MsgId)).First()
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return resp.ErrorResponseData("消息不存在"), errors.New("Business Error")
+		}
+		global.Logger.Errorf("查询消息失败 %s", err)
+		return resp.ErrorResponseData("系统繁忙，请稍后再试~"), errors.New("Business Error")
+	}
+	if msg.DeleteStatus != pkgEnum.NORMAL || msg.Type == enum.SystemMessageType {
+		return resp.ErrorResponseData("该消息不能置顶"), errors.New("Business Error")
+	}
+	canManage, err := canManageRoom(uid, msg.RoomID)
+	if err != nil {
+		return resp.ErrorResponseData("系统繁忙，请稍后再试~"), errors.New("Business Error")
+	}
+	if !canManage {
+		return resp.ErrorResponseData("权限不足"), errors.New("Business Error")
+	}
+	isBlocked, err := IsRoomBlocked(uid, msg.RoomID)
+	if err != nil {
+		return resp.ErrorResponseData("系统繁忙，请稍后再试~"), errors.New("Business Error")
+	}
+	if isBlocked {
+		return resp.ErrorResponseDataWithCode(pkgEnum.ERROR_USER_BLOCKED, nil), errors.New("Business Error")
+	}
+
+	// 同一房间的置顶串行处理，保证数量不超过上限
+	lock, err := utils.GetLock(fmt.Sprintf(enum.RoomPinLock, msg.RoomID))
+	if err != nil {
+		return resp.ErrorResponseData("系统繁忙，请稍后再试~"), errors.New("Business Error")
+	}
+	defer utils.ReleaseLock(lock)
+
+	messagePin := global.Query.MessagePin
+	messagePinQ := messagePin.WithContext(ctx)
+	exist, err := messagePinQ.Where(messagePin.RoomID.Eq(msg.RoomID), messagePin.MsgID.Eq(msg.ID)).Count()
+	if err != nil {
+		global.Logger.Errorf("查询置顶消息失败 %s", err)
+		return resp.ErrorResponseData("系统繁忙，请稍后再试~"), errors.New("Business Error")
+	}
+	if exist > 0 {
+		return resp.SuccessResponseDataWithMsg("已置顶"), nil
+	}
+	count, err := messagePinQ.Where(messagePin.RoomID.Eq(msg.RoomID)).Count()
+	if err != nil {
+		global.Logger.Errorf("查询置顶消息失败 %s", err)
+		return resp.ErrorResponseData("系统繁忙，请稍后再试~"), errors.New("Business Error")
+	}
+	if count >= getRoomPinLimit() {
+		return resp.ErrorResponseData("置顶消息数量已达上限"), errors.New("Business Error")
+	}
+
+	if err := savePinChange(uid, msg.RoomID, msg.ID, enum.MsgPin); err != nil {
+		return resp.ErrorResponseData("系统繁忙，请稍后再试~"), errors.New("Business Error")
+	}
+	return resp.SuccessResponseDataWithMsg("已置顶"), nil
+}
+
+// UnpinMsgService 取消置顶消息
+func UnpinMsgService(uid int64, unpinReq req.UnpinMsgReq) (resp.ResponseData, error) {
+	messagePin := global.Query.MessagePin
+	pinR, err := messagePin.WithContext(context.Background()).Where(messagePin.MsgID.Eq(unpinReq.MsgId)).First()
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return resp.SuccessResponseDataWithMsg("已取消置顶"), nil
+		}
+		global.Logger.Errorf("查询置顶消息失败 %s", err)
+		return resp.ErrorResponseData("系统繁忙，请稍后再试~"), errors.New("Business Error")
+	}
+	canManage, err := canManageRoom(uid, pinR.RoomID)
+	if err != nil {
+		return resp.ErrorResponseData("系统繁忙，请稍后再试~"), errors.New("Business Error")
+	}
+	if !canManage {
+		return resp.ErrorResponseData("权限不足"), errors.New("Business Error")
+	}
+
+	lock, err := utils.GetLock(fmt.Sprintf(enum.RoomPinLock, pinR.RoomID))
+	if err != nil {
+		return resp.ErrorResponseData("系统繁忙，请稍后再试~"), errors.New("Business Error")
+	}
+	defer utils.ReleaseLock(lock)
+	if err := savePinChange(uid, pinR.RoomID, pinR.MsgID, enum.MsgUnpin); err != nil {
+		return resp.ErrorResponseData("系统繁忙，请稍后再试~"), errors.New("Business Error")
+	}
+	return resp.SuccessResponseDataWithMsg("已取消置顶"), nil
+}
+
+// savePinChange 在同一事务中保存置顶变化、房间内的系统提示消息和置顶事件
+func savePinChange(uid, roomId, msgId int64, action int32) error {
+	ctx := context.Background()
+	userR, err := getUserByID(uid)
+	if err != nil {
+		global.Logger.Errorf("查询用户失败 %s", err)
+		return err
+	}
+	content := fmt.Sprintf("%s 置顶了一条消息", userR.Name)
+	if action == enum.MsgUnpin {
+		content = fmt.Sprintf("%s 取消置顶了一条消息", userR.Name)
+	}
+
+	tx := global.Query.Begin()
+	messagePin := global.Query.MessagePin
+	messagePinTx := tx.MessagePin.WithContext(ctx)
+	if action == enum.MsgPin {
+		err = messagePinTx.Create(&model.MessagePin{
+			RoomID: roomId,
+			MsgID:  msgId,
+			UID:    uid,
+		})
+	} else {
+		_, err = messagePinTx.Where(messagePin.RoomID.Eq(roomId), messagePin.MsgID.Eq(msgId)).Delete()
+	}
+	if err != nil {
+		if err := tx.Rollback(); err != nil {
+			global.Logger.Errorf("事务回滚失败 %s", err.Error())
+		}
+		global.Logger.Errorf("更新置顶消息失败 %s", err)
+		return err
+	}
+	// 系统提示消息
+	systemMsg := model.Message{
+		RoomID:     roomId,
+		FromUID:    uid,
+		Content:    content,
+		Type:       enum.SystemMessageType,
+		ReplyMsgID: msgId,
+		Extra:      "{}",
+	}
+	if err := SendTextMsgTx(tx, &systemMsg); err != nil {
+		if err := tx.Rollback(); err != nil {
+			global.Logger.Errorf("事务回滚失败 %s", err.Error())
+		}
+		return err
+	}
+	// 发送置顶变化事件
+	pinDto := dto.MsgPinDto{
+		RoomId: roomId,
+		MsgId:  msgId,
+		Uid:    uid,
+		Action: action,
+	}
+	if err := outbox.Save(tx, enum.MsgPinTopic, pinDto); err != nil {
+		if err := tx.Rollback(); err != nil {
+			global.Logger.Errorf("事务回滚失败 %s", err.Error())
+		}
+		global.Logger.Errorf("写入置顶事件失败 %s", err)
+		return err
+	}
+	if err := tx.Commit(); err != nil {
+		global.Logger.Errorf("事务提交失败 %s", err.Error())
+		return err
+	}
+	return nil
+}
+
+// GetPinnedMsgService 获取房间的置顶消息，最近置顶的排在前面
+func GetPinnedMsgService(uid int64, pinnedReq req.GetPinnedMsgReq) (resp.ResponseData, error) {
+	ctx := context.Background()
+	isMember, err := IsRoomMember(uid, pinnedReq.RoomId)
+	if err != nil {
+		return resp.ErrorResponseData("系统繁忙，请稍后再试~"), errors.New("Business Error")
+	}
+	if !isMember {
+		return resp.ErrorResponseData("无权访问该会话"), errors.New("Business Error")
+	}
+
+	messagePin := global.Query.MessagePin
+	pinList, err := messagePin.WithContext(ctx).Where(messagePin.RoomID.Eq(pinnedReq.RoomId)).Order(messagePin.CreateTime.Desc()).Find()
+	if err != nil {
+		global.Logger.Errorf("查询置顶消息失败 %s", err)
+		return resp.ErrorResponseData("系统繁忙，请稍后再试~"), errors.New("Business Error")
+	}
+	msgIds := make([]int64, 0, len(pinList))
+	for _, pin := range pinList {
+		msgIds = append(msgIds, pin.MsgID)
+	}
+	message := global.Query.Message
+	msgRList, err := message.WithContext(ctx).Where(message.ID.In(msgIds...), message.DeleteStatus.Eq(pkgEnum.NORMAL)).Find()
+	if err != nil {
+		global.Logger.Errorf("查询消息失败 %s", err)
+		return resp.ErrorResponseData("系统繁忙，请稍后再试~"), errors.New("Business Error")
+	}
+	msgList := make([]model.Message, 0, len(msgRList))
+	uids := make([]int64, 0, len(msgRList))
+	for _, msgR := range msgRList {
+		msgList = append(msgList, *msgR)
+		uids = append(uids, msgR.FromUID)
+	}
+	user := global.Query.User
+	users, err := user.WithContext(ctx).Where(user.ID.In(uids...)).Find()
+	if err != nil {
+		global.Logger.Errorf("查询用户失败 %s", err)
+		return resp.ErrorResponseData("系统繁忙，请稍后再试~"), errors.New("Business Error")
+	}
+	userMap := make(map[int64]*model.User)
+	for _, userR := range users {
+		userMap[userR.ID] = userR
+	}
+	blockedUids, err := getBlockedUids(uid)
+	if err != nil {
+		return resp.ErrorResponseData("系统繁忙，请稍后再试~"), errors.New("Business Error")
+	}
+	reactionMap, err := getMsgReactionMap(uid, msgIds)
+	if err != nil {
+		return resp.ErrorResponseData("系统繁忙，请稍后再试~"), errors.New("Business Error")
+	}
+
+	msgRespMap := make(map[int64]domainResp.MessageResp)
+	for _, msgResp := range adapter.BuildMessageRespByMsgAndUser(&msgList, userMap, blockedUids, reactionMap) {
+		msgRespMap[msgResp.Message.ID] = msgResp
+	}
+	pinnedRespList := make([]domainResp.PinnedMsgResp, 0, len(pinList))
+	for _, pin := range pinList {
+		msgResp, ok := msgRespMap[pin.MsgID]
+		if !ok {
+			continue
+		}
+		pinnedRespList = append(pinnedRespList, domainResp.PinnedMsgResp{
+			MessageResp: msgResp,
+			PinUid:      pin.UID,
+			PinTime:     pin.CreateTime.UnixMilli(),
+		})
+	}
+	return resp.SuccessResponseData(pinnedRespList), nil
+}
@@ -0,0 +1,266 @@
+package service
+
+import (
+	"DiTing-Go/dal/model"
+	"DiTing-Go/domain/dto"
+	"DiTing-Go/domain/enum"
+	"DiTing-Go/domain/vo/req"
+	domainResp "DiTing-Go/domain/vo/resp"
+	"DiTing-Go/global"
+	pkgEnum "DiTing-Go/pkg/domain/enum"
+	"DiTing-Go/pkg/domain/vo/resp"
+	"DiTing-Go/pkg/utils"
+	"DiTing-Go/utils/outbox"
+	"context"
+	"fmt"
+	"github.com/goccy/go-json"
+	"github.com/pkg/errors"
+	"gorm.io/gorm"
+	"slices"
+	"time"
+)
+
+// CreatePollService 在群聊中发起投票
+func CreatePollService(uid int64, pollReq req.CreatePollReq) (resp.ResponseData, error) {
+	deadline := time.UnixMilli(pollReq.Deadline)
+	if deadline.Before(time.Now().Add(enum.PollMinDuration)) || deadline.After(time.Now().Add(enum.PollMaxDuration)) {
+		return resp.ErrorResponseData("截止时间超出允许范围"), errors.New("Business Error")
+	}
+	for i, option := range pollReq.Options {
+		if slices.Contains(pollReq.Options[:i], option) {
+			return resp.ErrorResponseData("投票选项不能重复"), errors.New("Business Error")
+		}
+	}
+	roomR, err := getRoomByID(pollReq.RoomId)
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return resp.ErrorResponseData("会话不存在"), errors.New("Business Error")
+		}
+		global.Logger.Errorf("查询房间失败 %s", err)
+		return resp.ErrorResponseData("系统繁忙，请稍后再试~"), errors.New("Business Error")
+	}
+	if roomR.Type != enum.GROUP {
+		return resp.ErrorResponseData("只能在群聊中发起投票"), errors.New("Business Error")
+	}
+	isMember, err := IsRoomMember(uid, pollReq.RoomId)
+	if err != nil {
+		return resp.ErrorResponseData("系统繁忙，请稍后再试~"), errors.New("Business Error")
+	}
+	if !isMember {
+		return resp.ErrorResponseData("无权向该会话发送消息"), errors.New("Business Error")
+	}
+	userR, err := getUserByID(uid)
+	if err != nil {
+		global.Logger.Errorf("查询用户失败 %s", err)
+		return resp.ErrorResponseData("系统繁忙，请稍后再试~"), errors.New("Business Error")
+	}
+
+	extra, err := json.Marshal(dto.PollDto{
+		Question:  pollReq.Question,
+		Options:   pollReq.Options,
+		Multi:     pollReq.Multi,
+		Anonymous: pollReq.Anonymous,
+		Deadline:  pollReq.Deadline,
+	})
+	if err != nil {
+		global.Logger.Errorf("json序列化失败 %s", err)
+		return resp.ErrorResponseData("系统繁忙，请稍后再试~"), errors.New("Business Error")
+	}
+	msg := model.Message{
+		RoomID:  pollReq.RoomId,
+		FromUID: uid,
+		Content: enum.PollContentPrefix + pollReq.Question,
+		Type:    enum.PollMessageType,
+		Extra:   string(extra),
+	}
+	if err := SendTextMsg(&msg); err != nil {
+		return resp.ErrorResponseData("系统繁忙，请稍后再试~"), errors.New("Business Error")
+	}
+	return resp.SuccessResponseData(buildMessageResp(userR, &msg)), nil
+}
+
+// VotePollService 投票，重新投票时覆盖之前的选择
+func VotePollService(uid int64, voteReq req.VotePollReq) (resp.ResponseData, error) {
+	msg, poll, err := getPollMsg(voteReq.MsgId)
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return resp.ErrorResponseData("投票不存在"), errors.New("Business Error")
+		}
+		return resp.ErrorResponseData("系统繁忙，请稍后再试~"), errors.New("Business Error")
+	}
+	if time.Now().UnixMilli() >= poll.Deadline {
+		return resp.ErrorResponseData("投票已截止"), errors.New("Business Error")
+	}
+	options := uniqueOptions(voteReq.Options)
+	if !poll.Multi && len(options) > 1 {
+		return resp.ErrorResponseData("该投票为单选"), errors.New("Business Error")
+	}
+	for _, option := range options {
+		if int(option) >= len(poll.Options) {
+			return resp.ErrorResponseData("投票选项不存在"), errors.New("Business Error")
+		}
+	}
+	isMember, err := IsRoomMember(uid, msg.RoomID)
+	if err != nil {
+		return resp.ErrorResponseData("系统繁忙，请稍后再试~"), errors.New("Business Error")
+	}
+	if !isMember {
+		return resp.ErrorResponseData("投票不存在"), errors.New("Business Error")
+	}
+
+	// 同一用户的投票串行处理，避免并发投票产生多余的记录
+	lock, err := utils.GetLock(fmt.Sprintf(enum.PollVoteLock, msg.ID, uid))
+	if err != nil {
+		return resp.ErrorResponseData("系统繁忙，请稍后再试~"), errors.New("Business Error")
+	}
+	defer utils.ReleaseLock(lock)
+
+	ctx := context.Background()
+	tx := global.Query.Begin()
+	pollVote := global.Query.PollVote
+	pollVoteTx := tx.PollVote.WithContext(ctx)
+	if _, err := pollVoteTx.Where(pollVote.MsgID.Eq(msg.ID), pollVote.UID.Eq(uid)).Delete(); err != nil {
+		if err := tx.Rollback(); err != nil {
+			global.Logger.Errorf("事务回滚失败 %s", err.Error())
+		}
+		global.Logger.Errorf("删除投票记录失败 %s", err)
+		return resp.ErrorResponseData("系统繁忙，请稍后再试~"), errors.New("Business Error")
+	}
+	votes := make([]*model.PollVote, 0, len(options))
+	for _, option := range options {
+		votes = append(votes, &model.PollVote{
+			MsgID:     msg.ID,
+			UID:       uid,
+			OptionIdx: option,
+		})
+	}
+	if err := pollVoteTx.Create(votes...); err != nil {
+		if err := tx.Rollback(); err != nil {
+			global.Logger.Errorf("事务回滚失败 %s", err.Error())
+		}
+		global.Logger.Errorf("保存投票记录失败 %s", err)
+		return resp.ErrorResponseData("系统繁忙，请稍后再试~"), errors.New("Business Error")
+	}
+	// 发送投票事件
+	voteDto := dto.PollVoteDto{
+		MsgId:  msg.ID,
+		RoomId: msg.RoomID,
+		Uid:    uid,
+	}
+	if err := outbox.Save(tx, enum.PollVoteTopic, voteDto); err != nil {
+		if err := tx.Rollback(); err != nil {
+			global.Logger.Errorf("事务回滚失败 %s", err.Error())
+		}
+		global.Logger.Errorf("写入投票事件失败 %s", err)
+		return resp.ErrorResponseData("系统繁忙，请稍后再试~"), errors.New("Business Error")
+	}
+	if err := tx.Commit(); err != nil {
+		global.Logger.Errorf("事务提交失败 %s", err.Error())
+		return resp.ErrorResponseData("系统繁忙，请稍后再试~"), errors.New("Business Error")
+	}
+
+	pollResp, err := buildPollResp(uid, msg, poll)
+	if err != nil {
+		return resp.ErrorResponseData("系统繁忙，请稍后再试~"), errors.New("Business Error")
+	}
+	return resp.SuccessResponseData(pollResp), nil
+}
+
+// GetPollService 获取投票结果，截止后返回最终结果
+func GetPollService(uid int64, pollReq req.GetPollReq) (resp.ResponseData, error) {
+	msg, poll, err := getPollMsg(pollReq.MsgId)
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return resp.ErrorResponseData("投票不存在"), errors.New("Business Error")
+		}
+		return resp.ErrorResponseData("系统繁忙，请稍后再试~"), errors.New("Business Error")
+	}
+	isMember, err := IsRoomMember(uid, msg.RoomID)
+	if err != nil {
+		return resp.ErrorResponseData("系统繁忙，请稍后再试~"), errors.New("Business Error")
+	}
+	if !isMember {
+		return resp.ErrorResponseData("投票不存在"), errors.New("Business Error")
+	}
+	pollResp, err := buildPollResp(uid, msg, poll)
+	if err != nil {
+		return resp.ErrorResponseData("系统繁忙，请稍后再试~"), errors.New("Business Error")
+	}
+	return resp.SuccessResponseData(pollResp), nil
+}
+
+// getPollMsg 查询投票消息并解析投票内容，消息不存在或不是投票时返回 gorm.ErrRecordNotFound
+func getPollMsg(msgId int64) (*model.Message, *dto.PollDto, error) {
+	message := global.Query.Message
+	msg, err := message.WithContext(context.Background()).Where(message.ID.Eq(msgId), message.DeleteStatus.Eq(pkgEnum.NORMAL)).First()
+	if err != nil {
+		if !errors.Is(err, gorm.ErrRecordNotFound) {
+			global.Logger.Errorf("查询消息失败 %s", err)
+		}
+		return nil, nil, err
+	}
+	if msg.Type != enum.PollMessageType {
+		return nil, nil, gorm.ErrRecordNotFound
+	}
+	poll := dto.PollDto{}
+	if err := json.Unmarshal([]byte(msg.Extra), &poll); err != nil {
+		global.Logger.Errorf("json反序列化失败 %s", err)
+		return nil, nil, err
+	}
+	return msg, &poll, nil
+}
+
+// uniqueOptions 选项下标去重并排序
+func uniqueOptions(options []int32) []int32 {
+	options = slices.Clone(options)
+	slices.Sort(options)
+	return slices.Compact(options)
+}
+
+// buildPollResp 统计投票结果，匿名投票不返回投票人
+func buildPollResp(uid int64, msg *model.Message, poll *dto.PollDto) (*domainResp.PollResp, error) {
+	pollVote := global.Query.PollVote
+	votes, err := pollVote.WithContext(context.Background()).Where(pollVote.MsgID.Eq(msg.ID)).Order(pollVote.ID).Find()
+	if err != nil {
+		global.Logger.Errorf("查询投票记录失败 %s", err)
+		return nil, err
+	}
+	pollResp := domainResp.PollResp{
+		MsgId:     msg.ID,
+		RoomId:    msg.RoomID,
+		Question:  poll.Question,
+		Multi:     poll.Multi,
+		Anonymous: poll.Anonymous,
+		Deadline:  poll.Deadline,
+		Closed:    time.Now().UnixMilli() >= poll.Deadline,
+		Options:   make([]domainResp.PollOptionResp, 0, len(poll.Options)),
+	}
+	for i, option := range poll.Options {
+		pollResp.Options = append(pollResp.Options, domainResp.PollOptionResp{
+			Index: int32(i),
+			Text:  option,
+		})
+	}
+	tallyPollVotes(uid, &pollResp, votes)
+	return &pollResp, nil
+}
+
+// tallyPollVotes 按选项统计票数和投票人数，下标越界的投票忽略
+func tallyPollVotes(uid int64, pollResp *domainResp.PollResp, votes []*model.PollVote) {
+	voters := make(map[int64]bool)
+	for _, vote := range votes {
+		if vote.OptionIdx < 0 || int(vote.OptionIdx) >= len(pollResp.Options) {
+			continue
+		}
+		voters[vote.UID] = true
+		optionResp := &pollResp.Options[vote.OptionIdx]
+		optionResp.Count++
+		if vote.UID == uid {
+			optionResp.Voted = true
+		}
+		if !pollResp.Anonymous {
+			optionResp.Voters = append(optionResp.Voters, vote.UID)
+		}
+	}
+	pollResp.VoterCount = int64(len(voters))
+}
@@ -0,0 +1,83 @@
+//go:build integration
+
+package service
+
+import (
+	"DiTing-Go/dal/model"
+	domainResp "DiTing-Go/domain/vo/resp"
+	"slices"
+	"testing"
+)
+
+func TestUniqueOptions(t *testing.T) {
+	tests := []struct {
+		options []int32
+		want    []int32
+	}{
+		{options: []int32{2, 0, 2, 1, 0}, want: []int32{0, 1, 2}},
+		{options: []int32{1}, want: []int32{1}},
+		{options: []int32{}, want: []int32{}},
+	}
+	for _, tt := range tests {
+		options := slices.Clone(tt.options)
+		if got := uniqueOptions(options); !slices.Equal(got, tt.want) {
+			t.Errorf("uniqueOptions(%v) = %v, want %v", tt.options, got, tt.want)
+		}
+		if !slices.Equal(options, tt.options) {
+			t.Errorf("uniqueOptions modified its input: %v", options)
+		}
+	}
+}
+
+func TestTallyPollVotes(t *testing.T) {
+	votes := []*model.PollVote{
+		{UID: 1, OptionIdx: 0},
+		{UID: 1, OptionIdx: 1},
+		{UID: 2, OptionIdx: 1},
+		{UID: 3, OptionIdx: 5},
+		{UID: 4, OptionIdx: -1},
+	}
+	tests := []struct {
+		name       string
+		uid        int64
+		anonymous  bool
+		wantCounts []int64
+		wantVoted  []bool
+		wantVoters [][]int64
+	}{
+		{
+			name:       "实名投票",
+			uid:        2,
+			wantCounts: []int64{1, 2, 0},
+			wantVoted:  []bool{false, true, false},
+			wantVoters: [][]int64{{1}, {1, 2}, nil},
+		},
+		{
+			name:       "匿名投票不返回投票人",
+			uid:        1,
+			anonymous:  true,
+			wantCounts: []int64{1, 2, 0},
+			wantVoted:  []bool{true, true, false},
+			wantVoters: [][]int64{nil, nil, nil},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			pollResp := domainResp.PollResp{
+				Anonymous: tt.anonymous,
+				Options:   []domainResp.PollOptionResp{{Index: 0}, {Index: 1}, {Index: 2}},
+			}
+			tallyPollVotes(tt.uid, &pollResp, votes)
+			// 越界的投票不计入投票人数
+			if pollResp.VoterCount != 2 {
+				t.Errorf("VoterCount = %d, want 2", pollResp.VoterCount)
+			}
+			for i, option := range pollResp.Options {
+				if option.Count != tt.wantCounts[i] || option.Voted != tt.wantVoted[i] || !slices.Equal(option.Voters, tt.wantVoters[i]) {
+					t.Errorf("option %d = {Count:%d Voted:%v Voters:%v}, want {Count:%d Voted:%v Voters:%v}",
+						i, option.Count, option.Voted, option.Voters, tt.wantCounts[i], tt.wantVoted[i], tt.wantVoters[i])
+				}
+			}
+		})
+	}
+}
@@ -0,0 +1,211 @@
+package service
+
+import (
+	"DiTing-Go/dal/model"
+	"DiTing-Go/domain/dto"
+	domainEnum "DiTing-Go/domain/enum"
+	"DiTing-Go/domain/vo/req"
+	"DiTing-Go/domain/vo/resp"
+	"DiTing-Go/global"
+	pkgResp "DiTing-Go/pkg/domain/vo/resp"
+	"DiTing-Go/pkg/utils"
+	"DiTing-Go/utils/outbox"
+	"DiTing-Go/utils/redisCache"
+	"context"
+	"fmt"
+	"github.com/minio/minio-go/v7"
+	"github.com/pkg/errors"
+	"gorm.io/gen/field"
+	"gorm.io/gorm"
+	"net/url"
+	"strings"
+	"time"
+)
+
+// GetAvatarPreSignedService 签发头像上传地址
+func GetAvatarPreSignedService(uid int64, preSignedReq req.AvatarPreSignedReq) (pkgResp.ResponseData, error) {
+	rule := getUploadRuleByName(avatarUploadRule)
+	if err := rule.check(preSignedReq.Size, preSignedReq.ContentType); err != nil {
+		return pkgResp.ErrorResponseData(err.Error()), errors.New("Business Error")
+	}
+	// 预占当日上传额度
+	quotaDay := time.Now()
+	if err := reserveUploadQuota(uid, preSignedReq.Size); err != nil {
+		if errors.Is(err, errUploadQuotaExceed) {
+			return pkgResp.ErrorResponseData(err.Error()), errors.New("Business Error")
+		}
+		return pkgResp.ErrorResponseData("获取签名失败，请稍后再试"), errors.New("Business Error")
+	}
+	// 签名失败时归还预占的额度
+	signed := false
+	defer func() {
+		if !signed {
+			adjustUploadQuota(uid, quotaDay, -preSignedReq.Size)
+		}
+	}()
+
+	random, err := utils.RandomToken(16)
+	if err != nil {
+		global.Logger.Errorf("生成头像文件名失败 %s", err)
+		return pkgResp.ErrorResponseData("获取签名失败，请稍后再试"), errors.New("Business Error")
+	}
+	key := fmt.Sprintf(domainEnum.AvatarObjectKey, uid, random)
+
+	policy := minio.NewPostPolicy()
+	if err := policy.SetBucket(domainEnum.MinioBucket); err != nil {
+		global.Logger.Errorf("创建policy失败 %s", err)
+		return pkgResp.ErrorResponseData("获取签名失败，请稍后再试"), errors.New("Business Error")
+	}
+	if err := policy.SetKey(key); err != nil {
+		global.Logger.Errorf("创建policy失败 %s", err)
+		return pkgResp.ErrorResponseData("获取签名失败，请稍后再试"), errors.New("Business Error")
+	}
+	if err := policy.SetContentType(preSignedReq.ContentType); err != nil {
+		global.Logger.Errorf("创建policy失败 %s", err)
+		return pkgResp.ErrorResponseData("获取签名失败，请稍后再试"), errors.New("Business Error")
+	}
+	// 头像没有上传完成的确认步骤，按声明的大小限制上传，保证额度准确
+	if err := policy.SetContentLengthRange(1, preSignedReq.Size); err != nil {
+		global.Logger.Errorf("创建policy失败 %s", err)
+		return pkgResp.ErrorResponseData("获取签名失败，请稍后再试"), errors.New("Business Error")
+	}
+	if err := policy.SetExpires(time.Now().UTC().Add(time.Hour)); err != nil {
+		global.Logger.Errorf("创建policy失败 %s", err)
+		return pkgResp.ErrorResponseData("获取签名失败，请稍后再试"), errors.New("Business Error")
+	}
+	postUrl, formData, err := global.MinioClient.PresignedPostPolicy(context.Background(), policy)
+	if err != nil {
+		global.Logger.Errorf("创建policy失败 %s", err)
+		return pkgResp.ErrorResponseData("获取签名失败，请稍后再试"), errors.New("Business Error")
+	}
+	signed = true
+	return pkgResp.SuccessResponseData(resp.AvatarPreSignedResp{
+		Url:    postUrl.String(),
+		Policy: formData,
+		Avatar: fmt.Sprintf(domainEnum.AvatarUrl, url.QueryEscape(key)),
+	}), nil
+}
+
+// GetAvatarUrlService 签发头像的临时下载链接
+func GetAvatarUrlService(avatarReq req.AvatarReq) (string, error) {
+	if !strings.HasPrefix(avatarReq.Key, "avatar/") {
+		return "", errors.New("Business Error")
+	}
+	avatarUrl, err := global.MinioClient.PresignedGetObject(context.Background(), domainEnum.MinioBucket, avatarReq.Key, domainEnum.FileDownloadExpire, nil)
+	if err != nil {
+		global.Logger.Errorf("签发头像链接失败 %s", err)
+		return "", err
+	}
+	return avatarUrl.String(), nil
+}
+
+// checkAvatar 校验头像地址属于当前用户且已上传完成
+func checkAvatar(uid int64, avatar string) error {
+	prefix := fmt.Sprintf(domainEnum.AvatarUrl, "")
+	if !strings.HasPrefix(avatar, prefix) {
+		return errors.New("头像地址无效")
+	}
+	key, err := url.QueryUnescape(strings.TrimPrefix(avatar, prefix))
+	if err != nil || !strings.HasPrefix(key, fmt.Sprintf(domainEnum.AvatarObjectKey, uid, "")) {
+		return errors.New("头像地址无效")
+	}
+	info, err := global.MinioClient.StatObject(context.Background(), domainEnum.MinioBucket, key, minio.StatObjectOptions{})
+	if err != nil {
+		return errors.New("头像尚未上传完成")
+	}
+	if err := getUploadRuleByName(avatarUploadRule).check(info.Size, info.ContentType); err != nil {
+		return err
+	}
+	return nil
+}
+
+// UpdateProfileService 修改个人资料，资料变更后通知好友刷新
+func UpdateProfileService(uid int64, profileReq req.UpdateProfileReq) (pkgResp.ResponseData, error) {
+	ctx := context.Background()
+	user := global.Query.User
+	userQ := user.WithContext(ctx)
+
+	if profileReq.Name != nil {
+		name := strings.TrimSpace(*profileReq.Name)
+		if name == "" || name != *profileReq.Name {
+			return pkgResp.ErrorResponseData("昵称不能为空或包含首尾空格"), errors.New("Business Error")
+		}
+		// 昵称唯一，同一昵称的修改和注册串行执行
+		lock, err := utils.GetLock(fmt.Sprintf(domainEnum.UserNameLock, name))
+		if err != nil {
+			return pkgResp.ErrorResponseData("系统繁忙，请稍后再试~"), errors.New("Business Error")
+		}
+		defer utils.ReleaseLock(lock)
+
+		fun := func() (interface{}, error) {
+			return userQ.Where(user.Name.Eq(name)).First()
+		}
+		existUser := model.User{}
+		err = utils.GetData(fmt.Sprintf(domainEnum.UserCacheByName, name), &existUser, fun)
+		if err == nil && existUser.ID != uid {
+			return pkgResp.ErrorResponseData("昵称已存在"), errors.New("Business Error")
+		}
+		if err != nil && !errors.Is(err, gorm.ErrRecordNotFound) {
+			global.Logger.Errorf("查询数据失败: %v", err)
+			return pkgResp.ErrorResponseData("系统繁忙，请稍后再试~"), errors.New("Business Error")
+		}
+	}
+	if profileReq.Avatar != nil && *profileReq.Avatar != "" {
+		if err := checkAvatar(uid, *profileReq.Avatar); err != nil {
+			return pkgResp.ErrorResponseData(err.Error()), errors.New("Business Error")
+		}
+	}
+
+	userR, err := getUserByID(uid)
+	if err != nil {
+		global.Logger.Errorf("查询用户失败 %v", err)
+		return pkgResp.ErrorResponseData("系统繁忙，请稍后再试~"), errors.New("Business Error")
+	}
+
+	// 更新时间用于客户端判断是否需要刷新用户信息
+	columns := []field.AssignExpr{user.UpdateTime.Value(time.Now())}
+	if profileReq.Name != nil {
+		columns = append(columns, user.Name.Value(*profileReq.Name), user.NamePinyin.Value(utils.NamePinyin(*profileReq.Name)))
+	}
+	if profileReq.Avatar != nil {
+		columns = append(columns, user.Avatar.Value(*profileReq.Avatar))
+	}
+	if profileReq.Sex != nil {
+		columns = append(columns, user.Sex.Value(*profileReq.Sex))
+	}
+	if profileReq.Signature != nil {
+		columns = append(columns, user.Signature.Value(*profileReq.Signature))
+	}
+	if profileReq.Discoverable != nil {
+		columns = append(columns, user.Discoverable.Value(*profileReq.Discoverable))
+	}
+
+	tx := global.Query.Begin()
+	userTx := tx.User.WithContext(ctx)
+	if _, err := userTx.Where(user.ID.Eq(uid)).UpdateSimple(columns...); err != nil {
+		if err := tx.Rollback(); err != nil {
+			global.Logger.Errorf("事务回滚失败 %s", err.Error())
+		}
+		global.Logger.Errorf("更新用户资料失败 %s", err)
+		return pkgResp.ErrorResponseData("系统繁忙，请稍后再试~"), errors.New("Business Error")
+	}
+	// 发送资料变更事件
+	if err := outbox.Save(tx, domainEnum.UserProfileTopic, dto.UserProfileChangedDto{Uid: uid}); err != nil {
+		if err := tx.Rollback(); err != nil {
+			global.Logger.Errorf("事务回滚失败 %s", err.Error())
+		}
+		global.Logger.Errorf("写入资料变更事件失败 %s", err)
+		return pkgResp.ErrorResponseData("系统繁忙，请稍后再试~"), errors.New("Business Error")
+	}
+	if err := tx.Commit(); err != nil {
+		global.Logger.Errorf("事务提交失败 %s", err.Error())
+		return pkgResp.ErrorResponseData("系统繁忙，请稍后再试~"), errors.New("Business Error")
+	}
+
+	// 旧昵称和新昵称的缓存都要移除
+	redisCache.RemoveUserCache(*userR)
+	if profileReq.Name != nil {
+		redisCache.RemoveUserCache(model.User{ID: uid, Name: *profileReq.Name})
+	}
+	return pkgResp.SuccessResponseDataWithMsg("修改成功"), nil
+}
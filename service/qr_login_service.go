@@ -0,0 +1,173 @@
+package service
+
+import (
+	"DiTing-Go/domain/dto"
+	domainEnum "DiTing-Go/domain/enum"
+	"DiTing-Go/domain/vo/req"
+	"DiTing-Go/domain/vo/resp"
+	"DiTing-Go/global"
+	pkgResp "DiTing-Go/pkg/domain/vo/resp"
+	"DiTing-Go/pkg/utils"
+	"context"
+	"fmt"
+	"github.com/go-redis/redis"
+	"github.com/goccy/go-json"
+	"github.com/pkg/errors"
+	"time"
+)
+
+// getQrLoginTicket 查询扫码登录票据，票据不存在时返回 redis.Nil
+func getQrLoginTicket(ticket string) (*dto.QrLoginTicketDto, error) {
+	ticketDto := dto.QrLoginTicketDto{}
+	if err := utils.GetString(fmt.Sprintf(domainEnum.TokenQrLogin, ticket), &ticketDto); err != nil {
+		return nil, err
+	}
+	return &ticketDto, nil
+}
+
+// saveQrLoginTicket 保存扫码登录票据
+func saveQrLoginTicket(ticket string, ticketDto *dto.QrLoginTicketDto) error {
+	ticketByte, err := json.Marshal(ticketDto)
+	if err != nil {
+		global.Logger.Errorf("json序列化失败 %v", err)
+		return err
+	}
+	if err := global.Rdb.Set(fmt.Sprintf(domainEnum.TokenQrLogin, ticket), ticketByte, domainEnum.QrLoginExpire).Err(); err != nil {
+		global.Logger.Errorf("保存扫码登录票据失败 %v", err)
+		return err
+	}
+	return nil
+}
+
+// CreateQrLoginService 网页端创建扫码登录票据
+func CreateQrLoginService(createReq req.CreateQrLoginReq, ip string) (pkgResp.ResponseData, error) {
+	ticket, err := utils.RandomToken(24)
+	if err != nil {
+		global.Logger.Errorf("生成扫码登录票据失败 %v", err)
+		return pkgResp.ErrorResponseData("系统繁忙，请稍后再试~"), errors.New("Business Error")
+	}
+	ticketDto := dto.QrLoginTicketDto{
+		Status:     domainEnum.QrLoginWaiting,
+		Platform:   createReq.Platform,
+		DeviceName: createReq.DeviceName,
+		Ip:         ip,
+	}
+	if err := saveQrLoginTicket(ticket, &ticketDto); err != nil {
+		return pkgResp.ErrorResponseData("系统繁忙，请稍后再试~"), errors.New("Business Error")
+	}
+	return pkgResp.SuccessResponseData(resp.CreateQrLoginResp{
+		Ticket:    ticket,
+		ExpiresIn: int64(domainEnum.QrLoginExpire / time.Second),
+	}), nil
+}
+
+// PollQrLoginService 网页端长轮询票据状态，手机确认后领取令牌，令牌只能领取一次
+func PollQrLoginService(ctx context.Context, pollReq req.PollQrLoginReq) (pkgResp.ResponseData, error) {
+	timeout := time.NewTimer(domainEnum.QrLoginPollTimeout)
+	defer timeout.Stop()
+	ticker := time.NewTicker(domainEnum.QrLoginPollInterval)
+	defer ticker.Stop()
+	key := fmt.Sprintf(domainEnum.TokenQrLogin, pollReq.Ticket)
+	for {
+		ticketDto, err := getQrLoginTicket(pollReq.Ticket)
+		if errors.Is(err, redis.Nil) {
+			return pkgResp.SuccessResponseData(resp.PollQrLoginResp{Status: domainEnum.QrLoginExpired}), nil
+		} else if err != nil {
+			global.Logger.Errorf("查询扫码登录票据失败 %v", err)
+			return pkgResp.ErrorResponseData("系统繁忙，请稍后再试~"), errors.New("Business Error")
+		}
+
+		if ticketDto.Status == domainEnum.QrLoginConfirmed {
+			// 删除成功的请求才能领取令牌
+			n, err := global.Rdb.Del(key).Result()
+			if err != nil {
+				global.Logger.Errorf("删除扫码登录票据失败 %v", err)
+				return pkgResp.ErrorResponseData("系统繁忙，请稍后再试~"), errors.New("Business Error")
+			}
+			if n == 0 {
+				return pkgResp.SuccessResponseData(resp.PollQrLoginResp{Status: domainEnum.QrLoginExpired}), nil
+			}
+			userR, err := getUserByID(ticketDto.Uid)
+			if err != nil {
+				global.Logger.Errorf("查询用户失败 %v", err)
+				return pkgResp.ErrorResponseData("系统繁忙，请稍后再试~"), errors.New("Business Error")
+			}
+			// 票据中只保存会话ID，领取时再为会话签发令牌
+			session, err := getSession(ticketDto.Sid)
+			if errors.Is(err, redis.Nil) {
+				return pkgResp.SuccessResponseData(resp.PollQrLoginResp{Status: domainEnum.QrLoginExpired}), nil
+			} else if err != nil {
+				global.Logger.Errorf("查询会话失败 %v", err)
+				return pkgResp.ErrorResponseData("系统繁忙，请稍后再试~"), errors.New("Business Error")
+			}
+			tokenResp, err := issueTokens(session)
+			if err != nil {
+				return pkgResp.ErrorResponseData("系统繁忙，请稍后再试~"), errors.New("Business Error")
+			}
+			return pkgResp.SuccessResponseData(resp.PollQrLoginResp{
+				Status: domainEnum.QrLoginConfirmed,
+				Login: &resp.UserLoginResp{
+					Token:        tokenResp.Token,
+					RefreshToken: tokenResp.RefreshToken,
+					ExpiresIn:    int64(utils.GetAccessTokenExpire() / time.Second),
+					Uid:          userR.ID,
+					Name:         userR.Name,
+					Avatar:       userR.Avatar,
+				},
+			}), nil
+		}
+
+		select {
+		case <-ticker.C:
+		case <-timeout.C:
+			// 本次轮询超时，客户端重新发起
+			return pkgResp.SuccessResponseData(resp.PollQrLoginResp{Status: ticketDto.Status}), nil
+		case <-ctx.Done():
+			return pkgResp.SuccessResponseData(resp.PollQrLoginResp{Status: ticketDto.Status}), nil
+		}
+	}
+}
+
+// ConfirmQrLoginService 手机端确认扫码登录，为网页端创建会话并签发令牌
+func ConfirmQrLoginService(uid int64, confirmReq req.ConfirmQrLoginReq) (pkgResp.ResponseData, error) {
+	lock, err := utils.GetLock(fmt.Sprintf(domainEnum.QrLoginLock, confirmReq.Ticket))
+	if err != nil {
+		return pkgResp.ErrorResponseData("系统繁忙，请稍后再试~"), errors.New("Business Error")
+	}
+	defer utils.ReleaseLock(lock)
+
+	ticketDto, err := getQrLoginTicket(confirmReq.Ticket)
+	if errors.Is(err, redis.Nil) {
+		return pkgResp.ErrorResponseData("二维码已失效，请刷新后重试"), errors.New("Business Error")
+	} else if err != nil {
+		global.Logger.Errorf("查询扫码登录票据失败 %v", err)
+		return pkgResp.ErrorResponseData("系统繁忙，请稍后再试~"), errors.New("Business Error")
+	}
+	if ticketDto.Status != domainEnum.QrLoginWaiting {
+		return pkgResp.ErrorResponseData("二维码已失效，请刷新后重试"), errors.New("Business Error")
+	}
+
+	userR, err := getUserByID(uid)
+	if err != nil {
+		global.Logger.Errorf("查询用户失败 %v", err)
+		return pkgResp.ErrorResponseData("系统繁忙，请稍后再试~"), errors.New("Business Error")
+	}
+	// 为网页端创建独立的会话，此处签发的令牌不保存，网页端领取时重新签发
+	sid, _, err := createSession(uid)
+	if err != nil {
+		return pkgResp.ErrorResponseData("系统繁忙，请稍后再试~"), errors.New("Business Error")
+	}
+	if err := registerDevice(userR, sid, ticketDto.Platform, ticketDto.DeviceName, ticketDto.Ip); err != nil {
+		_ = revokeSession(uid, sid)
+		return pkgResp.ErrorResponseData("系统繁忙，请稍后再试~"), errors.New("Business Error")
+	}
+
+	ticketDto.Status = domainEnum.QrLoginConfirmed
+	ticketDto.Uid = uid
+	ticketDto.Sid = sid
+	if err := saveQrLoginTicket(confirmReq.Ticket, ticketDto); err != nil {
+		_ = revokeSession(uid, sid)
+		return pkgResp.ErrorResponseData("系统繁忙，请稍后再试~"), errors.New("Business Error")
+	}
+	return pkgResp.SuccessResponseDataWithMsg("登录成功"), nil
+}
@@ -0,0 +1,149 @@
+package service
+
+import (
+	"DiTing-Go/dal"
+	"DiTing-Go/dal/model"
+	"DiTing-Go/domain/dto"
+	"DiTing-Go/domain/enum"
+	"DiTing-Go/domain/vo/req"
+	domainResp "DiTing-Go/domain/vo/resp"
+	"DiTing-Go/global"
+	pkgEnum "DiTing-Go/pkg/domain/enum"
+	"DiTing-Go/pkg/domain/vo/resp"
+	"DiTing-Go/pkg/utils"
+	"DiTing-Go/utils/outbox"
+	"context"
+	"fmt"
+	"github.com/pkg/errors"
+	"gorm.io/gorm"
+)
+
+// reactionCount 消息的表情回应统计
+type reactionCount struct {
+	MsgID   int64
+	Emoji   string
+	Count   int64
+	Reacted bool
+}
+
+// ReactionService 添加或取消消息的表情回应
+func ReactionService(uid int64, reactionReq req.ReactionReq) (resp.ResponseData, error) {
+	ctx := context.Background()
+	message := global.Query.Message
+	msg, err := message.WithContext(ctx).Where(message.ID.Eq(reactionReq.MsgId)).First()
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return resp.ErrorResponseData("消息不存在"), errors.New("Business Error")
+		}
+		global.Logger.Errorf("查询消息失败 %s", err)
+		return resp.ErrorResponseData("系统繁忙，请稍后再试~"), errors.New("Business Error")
+	}
+	if msg.DeleteStatus != pkgEnum.NORMAL {
+		return resp.ErrorResponseData("消息不存在"), errors.New("Business Error")
+	}
+	isMember, err := IsRoomMember(uid, msg.RoomID)
+	if err != nil {
+		return resp.ErrorResponseData("系统繁忙，请稍后再试~"), errors.New("Business Error")
+	}
+	if !isMember {
+		return resp.ErrorResponseData("消息不存在"), errors.New("Business Error")
+	}
+	isBlocked, err := IsRoomBlocked(uid, msg.RoomID)
+	if err != nil {
+		return resp.ErrorResponseData("系统繁忙，请稍后再试~"), errors.New("Business Error")
+	}
+	if isBlocked {
+		return resp.ErrorResponseDataWithCode(pkgEnum.ERROR_USER_BLOCKED, nil), errors.New("Business Error")
+	}
+
+	// 同一消息的回应串行处理，保证表情种类数不超过上限
+	lock, err := utils.GetLock(fmt.Sprintf(enum.MsgReactionLock, msg.ID))
+	if err != nil {
+		return resp.ErrorResponseData("系统繁忙，请稍后再试~"), errors.New("Business Error")
+	}
+	defer utils.ReleaseLock(lock)
+
+	msgReaction := global.Query.MessageReaction
+	msgReactionQ := msgReaction.WithContext(ctx)
+	exist, err := msgReactionQ.Where(msgReaction.MsgID.Eq(msg.ID), msgReaction.UID.Eq(uid), msgReaction.Emoji.Eq(reactionReq.Emoji)).Count()
+	if err != nil {
+		global.Logger.Errorf("查询表情回应失败 %s", err)
+		return resp.ErrorResponseData("系统繁忙，请稍后再试~"), errors.New("Business Error")
+	}
+	// 重复添加或取消直接返回成功
+	if (reactionReq.Action == enum.ReactionAdd) == (exist > 0) {
+		return resp.SuccessResponseData(nil), nil
+	}
+	if reactionReq.Action == enum.ReactionAdd {
+		emojiCount := int64(0)
+		if err := dal.DB.Model(&model.MessageReaction{}).Where("msg_id = ? and emoji != ?", msg.ID, reactionReq.Emoji).Distinct("emoji").Count(&emojiCount).Error; err != nil {
+			global.Logger.Errorf("统计表情种类失败 %s", err)
+			return resp.ErrorResponseData("系统繁忙，请稍后再试~"), errors.New("Business Error")
+		}
+		if emojiCount >= enum.MsgReactionEmojiLimit {
+			return resp.ErrorResponseData("该消息的表情数量已达上限"), errors.New("Business Error")
+		}
+	}
+
+	tx := global.Query.Begin()
+	msgReactionTx := tx.MessageReaction.WithContext(ctx)
+	if reactionReq.Action == enum.ReactionAdd {
+		err = msgReactionTx.Create(&model.MessageReaction{
+			MsgID: msg.ID,
+			UID:   uid,
+			Emoji: reactionReq.Emoji,
+		})
+	} else {
+		_, err = msgReactionTx.Where(msgReaction.MsgID.Eq(msg.ID), msgReaction.UID.Eq(uid), msgReaction.Emoji.Eq(reactionReq.Emoji)).Delete()
+	}
+	if err != nil {
+		if err := tx.Rollback(); err != nil {
+			global.Logger.Errorf("事务回滚失败 %s", err.Error())
+		}
+		global.Logger.Errorf("更新表情回应失败 %s", err)
+		return resp.ErrorResponseData("系统繁忙，请稍后再试~"), errors.New("Business Error")
+	}
+	// 发送表情回应事件
+	reactionDto := dto.MsgReactionDto{
+		MsgId:  msg.ID,
+		RoomId: msg.RoomID,
+		Uid:    uid,
+		Emoji:  reactionReq.Emoji,
+		Action: reactionReq.Action,
+	}
+	if err := outbox.Save(tx, enum.MsgReactionTopic, reactionDto); err != nil {
+		if err := tx.Rollback(); err != nil {
+			global.Logger.Errorf("事务回滚失败 %s", err.Error())
+		}
+		global.Logger.Errorf("写入表情回应事件失败 %s", err)
+		return resp.ErrorResponseData("系统繁忙，请稍后再试~"), errors.New("Business Error")
+	}
+	if err := tx.Commit(); err != nil {
+		global.Logger.Errorf("事务提交失败 %s", err.Error())
+		return resp.ErrorResponseData("系统繁忙，请稍后再试~"), errors.New("Business Error")
+	}
+	return resp.SuccessResponseData(nil), nil
+}
+
+// getMsgReactionMap 统计消息的表情回应，按表情首次出现的顺序排列
+func getMsgReactionMap(uid int64, msgIds []int64) (map[int64][]domainResp.ReactionResp, error) {
+	reactionMap := make(map[int64][]domainResp.ReactionResp)
+	if len(msgIds) == 0 {
+		return reactionMap, nil
+	}
+	counts := make([]reactionCount, 0)
+	if err := dal.DB.Raw(`select msg_id, emoji, count(*) as count, max(uid = ?) as reacted
+		from message_reaction where msg_id in ?
+		group by msg_id, emoji order by min(id)`, uid, msgIds).Scan(&counts).Error; err != nil {
+		global.Logger.Errorf("统计表情回应失败 %s", err)
+		return nil, err
+	}
+	for _, count := range counts {
+		reactionMap[count.MsgID] = append(reactionMap[count.MsgID], domainResp.ReactionResp{
+			Emoji:   count.Emoji,
+			Count:   count.Count,
+			Reacted: count.Reacted,
+		})
+	}
+	return reactionMap, nil
+}
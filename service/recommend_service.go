@@ -0,0 +1,172 @@
+package service
+
+import (
+	"DiTing-Go/dal"
+	"DiTing-Go/dal/model"
+	"DiTing-Go/domain/dto"
+	domainEnum "DiTing-Go/domain/enum"
+	"DiTing-Go/domain/vo/req"
+	"DiTing-Go/domain/vo/resp"
+	"DiTing-Go/global"
+	pkgEnum "DiTing-Go/pkg/domain/enum"
+	pkgResp "DiTing-Go/pkg/domain/vo/resp"
+	"DiTing-Go/pkg/utils"
+	"context"
+	"fmt"
+	"github.com/go-redis/redis"
+	"github.com/goccy/go-json"
+	"github.com/pkg/errors"
+	"sort"
+)
+
+// recommendCount 候选人及其计数
+type recommendCount struct {
+	Uid   int64
+	Count int64
+}
+
+// GetRecommendService 获取好友推荐，按共同好友和共同群聊排序
+func GetRecommendService(uid int64, recommendReq req.RecommendReq) (pkgResp.ResponseData, error) {
+	size := recommendReq.Size
+	if size <= 0 {
+		size = domainEnum.RecommendDefaultSize
+	}
+	recommendList, err := getRecommendList(uid)
+	if err != nil {
+		return pkgResp.ErrorResponseData("系统繁忙，请稍后再试~"), errors.New("Business Error")
+	}
+	recommendList = recommendList[:min(size, len(recommendList))]
+
+	uids := make([]int64, 0, len(recommendList))
+	for _, recommend := range recommendList {
+		uids = append(uids, recommend.Uid)
+	}
+	user := global.Query.User
+	userQ := user.WithContext(context.Background())
+	users, err := userQ.Select(user.ID, user.Name, user.Avatar).Where(user.ID.In(uids...)).Find()
+	if err != nil {
+		global.Logger.Errorf("查询用户失败 %s", err)
+		return pkgResp.ErrorResponseData("系统繁忙，请稍后再试~"), errors.New("Business Error")
+	}
+	userMap := make(map[int64]*model.User)
+	for _, userR := range users {
+		userMap[userR.ID] = userR
+	}
+
+	recommendRespList := make([]resp.RecommendResp, 0, len(recommendList))
+	for _, recommend := range recommendList {
+		userR, ok := userMap[recommend.Uid]
+		if !ok {
+			continue
+		}
+		recommendRespList = append(recommendRespList, resp.RecommendResp{
+			Uid:           recommend.Uid,
+			Name:          userR.Name,
+			Avatar:        userR.Avatar,
+			MutualFriends: recommend.MutualFriends,
+			SharedGroups:  recommend.SharedGroups,
+		})
+	}
+	return pkgResp.SuccessResponseData(recommendRespList), nil
+}
+
+// getRecommendList 优先读取缓存，缓存失效后重新计算
+// 好友、拉黑、申请关系变化时会移除缓存，其余变化等缓存过期
+func getRecommendList(uid int64) ([]dto.RecommendDto, error) {
+	key := fmt.Sprintf(domainEnum.UserRecommendCache, uid)
+	recommendList := make([]dto.RecommendDto, 0)
+	err := utils.GetString(key, &recommendList)
+	if err == nil {
+		return recommendList, nil
+	}
+	if !errors.Is(err, redis.Nil) {
+		global.Logger.Errorf("查询好友推荐缓存失败 %s", err)
+	}
+
+	recommendList, err = computeRecommendList(uid)
+	if err != nil {
+		return nil, err
+	}
+	recommendByte, err := json.Marshal(recommendList)
+	if err != nil {
+		global.Logger.Errorf("json序列化失败 %s", err)
+		return recommendList, nil
+	}
+	if err := global.Rdb.Set(key, recommendByte, domainEnum.RecommendExpire).Err(); err != nil {
+		global.Logger.Errorf("写入好友推荐缓存失败 %s", err)
+	}
+	return recommendList, nil
+}
+
+// computeRecommendList 统计共同好友和共同群聊，排除自己、好友、拉黑和待处理申请的用户
+func computeRecommendList(uid int64) ([]dto.RecommendDto, error) {
+	// 好友的好友
+	mutualFriends := make([]recommendCount, 0)
+	args := append([]interface{}{uid, pkgEnum.NORMAL, pkgEnum.NORMAL, uid}, recommendExcludeArgs(uid)...)
+	if err := dal.DB.Raw(`select f2.friend_uid as uid, count(*) as count
+		from user_friend f1 join user_friend f2 on f1.friend_uid = f2.uid
+		where f1.uid = ? and f1.delete_status = ? and f2.delete_status = ? and f2.friend_uid != ?
+		and `+recommendExcludeSql("f2.friend_uid")+`
+		group by f2.friend_uid order by count desc limit ?`,
+		append(args, domainEnum.RecommendCandidateLimit)...).Scan(&mutualFriends).Error; err != nil {
+		global.Logger.Errorf("统计共同好友失败 %s", err)
+		return nil, err
+	}
+	// 同群的成员
+	sharedGroups := make([]recommendCount, 0)
+	args = append([]interface{}{uid, uid}, recommendExcludeArgs(uid)...)
+	if err := dal.DB.Raw(`select g2.uid as uid, count(*) as count
+		from group_member g1 join group_member g2 on g1.group_id = g2.group_id
+		where g1.uid = ? and g2.uid != ?
+		and `+recommendExcludeSql("g2.uid")+`
+		group by g2.uid order by count desc limit ?`,
+		append(args, domainEnum.RecommendCandidateLimit)...).Scan(&sharedGroups).Error; err != nil {
+		global.Logger.Errorf("统计共同群聊失败 %s", err)
+		return nil, err
+	}
+
+	candidateMap := make(map[int64]*dto.RecommendDto)
+	getCandidate := func(candidateUid int64) *dto.RecommendDto {
+		if candidate, ok := candidateMap[candidateUid]; ok {
+			return candidate
+		}
+		candidate := &dto.RecommendDto{Uid: candidateUid}
+		candidateMap[candidateUid] = candidate
+		return candidate
+	}
+	for _, mutualFriend := range mutualFriends {
+		getCandidate(mutualFriend.Uid).MutualFriends = mutualFriend.Count
+	}
+	for _, sharedGroup := range sharedGroups {
+		getCandidate(sharedGroup.Uid).SharedGroups = sharedGroup.Count
+	}
+	if len(candidateMap) == 0 {
+		return []dto.RecommendDto{}, nil
+	}
+
+	recommendList := make([]dto.RecommendDto, 0, len(candidateMap))
+	for _, candidate := range candidateMap {
+		candidate.Score = candidate.MutualFriends*domainEnum.RecommendMutualFriendWeight + candidate.SharedGroups*domainEnum.RecommendSharedGroupWeight
+		recommendList = append(recommendList, *candidate)
+	}
+	sort.Slice(recommendList, func(i, j int) bool {
+		if recommendList[i].Score != recommendList[j].Score {
+			return recommendList[i].Score > recommendList[j].Score
+		}
+		return recommendList[i].Uid < recommendList[j].Uid
+	})
+	return recommendList[:min(domainEnum.RecommendCacheSize, len(recommendList))], nil
+}
+
+// recommendExcludeSql 排除候选人的条件：已是好友、互相拉黑、存在待处理的申请
+// 在 limit 之前过滤，避免候选名额被好友占满，column 为候选人uid所在的列
+func recommendExcludeSql(column string) string {
+	return fmt.Sprintf(`not exists (select 1 from user_friend uf where uf.uid = ? and uf.friend_uid = %[1]s and uf.delete_status = ?)
+		and not exists (select 1 from user_block ub where ((ub.uid = ? and ub.target_uid = %[1]s) or (ub.uid = %[1]s and ub.target_uid = ?)) and ub.delete_status = ?)
+		and not exists (select 1 from user_apply ua where ((ua.uid = ? and ua.target_id = %[1]s) or (ua.uid = %[1]s and ua.target_id = ?)) and ua.status = ?)`, column)
+}
+
+// recommendExcludeArgs recommendExcludeSql 的参数
+func recommendExcludeArgs(uid int64) []interface{} {
+	return []interface{}{uid, pkgEnum.NORMAL, uid, uid, pkgEnum.NORMAL, uid, uid, domainEnum.ApplyWaiting}
+}
@@ -0,0 +1,122 @@
+package service
+
+import (
+	"DiTing-Go/dal/model"
+	"DiTing-Go/dal/query"
+	"DiTing-Go/domain/enum"
+	"DiTing-Go/global"
+	pkgEnum "DiTing-Go/pkg/domain/enum"
+	"DiTing-Go/pkg/utils"
+	"context"
+	"fmt"
+	"github.com/pkg/errors"
+	"gorm.io/gorm"
+)
+
+// IsRoomMember 判断用户是否在房间中
+// 单聊要求好友关系未解除，群聊要求用户仍是群成员
+func IsRoomMember(uid, roomId int64) (bool, error) {
+	ctx := context.Background()
+	roomQ := global.Query.WithContext(ctx).Room
+	fun := func() (interface{}, error) {
+		return roomQ.Where(query.Room.ID.Eq(roomId)).First()
+	}
+	roomR := model.Room{}
+	key := fmt.Sprintf(enum.RoomCacheByID, roomId)
+	if err := utils.GetData(key, &roomR, fun); err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return false, nil
+		}
+		global.Logger.Errorf("查询房间失败 %s", err)
+		return false, err
+	}
+
+	if roomR.Type == enum.PERSONAL {
+		roomFriend := global.Query.RoomFriend
+		roomFriendQ := roomFriend.WithContext(ctx)
+		roomFriendR, err := roomFriendQ.Where(roomFriend.RoomID.Eq(roomId)).First()
+		if err != nil {
+			if errors.Is(err, gorm.ErrRecordNotFound) {
+				return false, nil
+			}
+			global.Logger.Errorf("查询好友房间失败 %s", err)
+			return false, err
+		}
+		if roomFriendR.Uid1 != uid && roomFriendR.Uid2 != uid {
+			return false, nil
+		}
+		// 删除好友后房间被禁用
+		if roomFriendR.DeleteStatus == pkgEnum.DELETED {
+			return false, nil
+		}
+		friendUid := roomFriendR.Uid1
+		if friendUid == uid {
+			friendUid = roomFriendR.Uid2
+		}
+		return IsFriend(uid, friendUid)
+	}
+
+	roomGroup := global.Query.RoomGroup
+	roomGroupQ := roomGroup.WithContext(ctx)
+	roomGroupR, err := roomGroupQ.Where(roomGroup.RoomID.Eq(roomId)).First()
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return false, nil
+		}
+		global.Logger.Errorf("查询群聊失败 %s", err)
+		return false, err
+	}
+	groupMember := global.Query.GroupMember
+	groupMemberQ := groupMember.WithContext(ctx)
+	count, err := groupMemberQ.Where(groupMember.UID.Eq(uid), groupMember.GroupID.Eq(roomGroupR.ID)).Count()
+	if err != nil {
+		global.Logger.Errorf("查询群组成员表失败 %s", err)
+		return false, err
+	}
+	return count > 0, nil
+}
+
+// getRoomByID 查询房间，优先读取缓存
+func getRoomByID(roomId int64) (*model.Room, error) {
+	roomQ := global.Query.WithContext(context.Background()).Room
+	fun := func() (interface{}, error) {
+		return roomQ.Where(query.Room.ID.Eq(roomId)).First()
+	}
+	roomR := model.Room{}
+	key := fmt.Sprintf(enum.RoomCacheByID, roomId)
+	if err := utils.GetData(key, &roomR, fun); err != nil {
+		return nil, err
+	}
+	return &roomR, nil
+}
+
+// canManageRoom 判断用户能否管理房间（置顶消息、消息自毁等），群聊要求群主或管理员，单聊双方都可以
+func canManageRoom(uid, roomId int64) (bool, error) {
+	isMember, err := IsRoomMember(uid, roomId)
+	if err != nil || !isMember {
+		return false, err
+	}
+	roomR, err := getRoomByID(roomId)
+	if err != nil {
+		global.Logger.Errorf("查询房间失败 %s", err)
+		return false, err
+	}
+	if roomR.Type == enum.PERSONAL {
+		return true, nil
+	}
+
+	ctx := context.Background()
+	roomGroup := global.Query.RoomGroup
+	roomGroupR, err := roomGroup.WithContext(ctx).Where(roomGroup.RoomID.Eq(roomId)).First()
+	if err != nil {
+		global.Logger.Errorf("查询群聊失败 %s", err)
+		return false, err
+	}
+	groupMember := global.Query.GroupMember
+	groupMemberR, err := groupMember.WithContext(ctx).Where(groupMember.UID.Eq(uid), groupMember.GroupID.Eq(roomGroupR.ID)).First()
+	if err != nil {
+		global.Logger.Errorf("查询群组成员表失败 %s", err)
+		return false, err
+	}
+	return groupMemberR.Role == enum.GroupOwner || groupMemberR.Role == enum.GroupAdmin, nil
+}
@@ -0,0 +1,221 @@
+package service
+
+import (
+	"DiTing-Go/dal/model"
+	"DiTing-Go/dal/query"
+	"DiTing-Go/domain/enum"
+	"DiTing-Go/domain/vo/req"
+	"DiTing-Go/global"
+	pkgEnum "DiTing-Go/pkg/domain/enum"
+	"DiTing-Go/pkg/domain/vo/resp"
+	"DiTing-Go/utils/redisCache"
+	"context"
+	"fmt"
+	"github.com/go-redsync/redsync/v4"
+	"github.com/pkg/errors"
+	"gorm.io/gorm"
+	"time"
+)
+
+// UpdateRoomTtlService 设置房间的消息自毁时长，只对之后发送的消息生效
+func UpdateRoomTtlService(uid int64, ttlReq req.UpdateRoomTtlReq) (resp.ResponseData, error) {
+	canManage, err := canManageRoom(uid, ttlReq.RoomId)
+	if err != nil {
+		return resp.ErrorResponseData("系统繁忙，请稍后再试~"), errors.New("Business Error")
+	}
+	if !canManage {
+		return resp.ErrorResponseData("权限不足"), errors.New("Business Error")
+	}
+	roomR, err := getRoomByID(ttlReq.RoomId)
+	if err != nil {
+		global.Logger.Errorf("查询房间失败 %s", err)
+		return resp.ErrorResponseData("系统繁忙，请稍后再试~"), errors.New("Business Error")
+	}
+	if roomR.MsgTTL == ttlReq.Ttl {
+		return resp.SuccessResponseDataWithMsg("设置成功"), nil
+	}
+	userR, err := getUserByID(uid)
+	if err != nil {
+		global.Logger.Errorf("查询用户失败 %s", err)
+		return resp.ErrorResponseData("系统繁忙，请稍后再试~"), errors.New("Business Error")
+	}
+	content := fmt.Sprintf("%s 关闭了消息自毁", userR.Name)
+	if ttlReq.Ttl > 0 {
+		content = fmt.Sprintf("%s 开启了消息自毁，新消息将在%s后删除", userR.Name, msgTtlText(ttlReq.Ttl))
+	}
+
+	ctx := context.Background()
+	tx := global.Query.Begin()
+	room := global.Query.Room
+	if _, err := tx.Room.WithContext(ctx).Where(room.ID.Eq(ttlReq.RoomId)).Update(room.MsgTTL, ttlReq.Ttl); err != nil {
+		if err := tx.Rollback(); err != nil {
+			global.Logger.Errorf("事务回滚失败 %s", err.Error())
+		}
+		global.Logger.Errorf("更新房间消息自毁时长失败 %s", err)
+		return resp.ErrorResponseData("系统繁忙，请稍后再试~"), errors.New("Business Error")
+	}
+	// 系统提示消息
+	systemMsg := model.Message{
+		RoomID:  ttlReq.RoomId,
+		FromUID: uid,
+		Content: content,
+		Type:    enum.SystemMessageType,
+		Extra:   "{}",
+	}
+	if err := SendTextMsgTx(tx, &systemMsg); err != nil {
+		if err := tx.Rollback(); err != nil {
+			global.Logger.Errorf("事务回滚失败 %s", err.Error())
+		}
+		return resp.ErrorResponseData("系统繁忙，请稍后再试~"), errors.New("Business Error")
+	}
+	if err := tx.Commit(); err != nil {
+		global.Logger.Errorf("事务提交失败 %s", err.Error())
+		return resp.ErrorResponseData("系统繁忙，请稍后再试~"), errors.New("Business Error")
+	}
+	redisCache.RemoveRoomCache(*roomR)
+	return resp.SuccessResponseDataWithMsg("设置成功"), nil
+}
+
+// msgTtlText 自毁时长的展示文案
+func msgTtlText(ttl int32) string {
+	duration := time.Duration(ttl) * time.Second
+	if duration%(24*time.Hour) == 0 {
+		return fmt.Sprintf("%d天", duration/(24*time.Hour))
+	}
+	return fmt.Sprintf("%d小时", duration/time.Hour)
+}
+
+// stampMsgExpire 按房间的自毁时长设置消息的自毁时间
+// 在事务中读取房间，保证修改自毁时长后发送的消息立即生效
+func stampMsgExpire(tx *query.QueryTx, msg *model.Message) error {
+	room := global.Query.Room
+	roomR, err := tx.Room.WithContext(context.Background()).Select(room.MsgTTL).Where(room.ID.Eq(msg.RoomID)).First()
+	if err != nil {
+		global.Logger.Errorf("查询房间失败 %s", err)
+		return err
+	}
+	if roomR.MsgTTL > 0 {
+		msg.ExpireAt = msg.CreateTime.Add(time.Duration(roomR.MsgTTL) * time.Second)
+	}
+	return nil
+}
+
+// ExpireMsgJob 周期性地删除到达自毁时间的消息
+func ExpireMsgJob() {
+	ticker := time.NewTicker(enum.MsgExpireInterval)
+	defer ticker.Stop()
+	for range ticker.C {
+		expireMsg()
+	}
+}
+
+func expireMsg() {
+	// 多实例部署时只需要一个实例执行
+	mutex := global.RedSync.NewMutex(enum.MsgExpireLock, redsync.WithExpiry(10*time.Minute))
+	if err := mutex.TryLock(); err != nil {
+		return
+	}
+	defer mutex.Unlock()
+
+	ctx := context.Background()
+	message := global.Query.Message
+	messageQ := message.WithContext(ctx)
+	for {
+		msgList, err := messageQ.Where(message.ExpireAt.Lte(time.Now()), message.DeleteStatus.Eq(pkgEnum.NORMAL)).Limit(enum.MsgExpireBatchSize).Find()
+		if err != nil {
+			global.Logger.Errorf("查询自毁消息失败 %s", err)
+			return
+		}
+		if len(msgList) == 0 {
+			return
+		}
+		ids := make([]int64, 0, len(msgList))
+		roomIds := make([]int64, 0)
+		for _, msg := range msgList {
+			ids = append(ids, msg.ID)
+			roomIds = append(roomIds, msg.RoomID)
+		}
+		if err := deleteExpiredMsg(ids); err != nil {
+			return
+		}
+		for _, roomId := range uniqueIds(roomIds) {
+			refreshRoomLastMsg(roomId, ids)
+		}
+		releaseObjectRefs(enum.ObjectRefMessage, ids)
+		if len(msgList) < enum.MsgExpireBatchSize {
+			return
+		}
+	}
+}
+
+// deleteExpiredMsg 在同一事务中删除自毁消息及其置顶、表情回应和投票记录
+// 置顶不清理会继续占用房间的置顶名额
+func deleteExpiredMsg(ids []int64) error {
+	ctx := context.Background()
+	tx := global.Query.Begin()
+	message := global.Query.Message
+	if _, err := tx.Message.WithContext(ctx).Where(message.ID.In(ids...), message.DeleteStatus.Eq(pkgEnum.NORMAL)).Update(message.DeleteStatus, pkgEnum.DELETED); err != nil {
+		if err := tx.Rollback(); err != nil {
+			global.Logger.Errorf("事务回滚失败 %s", err.Error())
+		}
+		global.Logger.Errorf("删除自毁消息失败 %s", err)
+		return err
+	}
+	messagePin := global.Query.MessagePin
+	if _, err := tx.MessagePin.WithContext(ctx).Where(messagePin.MsgID.In(ids...)).Delete(); err != nil {
+		if err := tx.Rollback(); err != nil {
+			global.Logger.Errorf("事务回滚失败 %s", err.Error())
+		}
+		global.Logger.Errorf("删除置顶消息失败 %s", err)
+		return err
+	}
+	msgReaction := global.Query.MessageReaction
+	if _, err := tx.MessageReaction.WithContext(ctx).Where(msgReaction.MsgID.In(ids...)).Delete(); err != nil {
+		if err := tx.Rollback(); err != nil {
+			global.Logger.Errorf("事务回滚失败 %s", err.Error())
+		}
+		global.Logger.Errorf("删除表情回应失败 %s", err)
+		return err
+	}
+	pollVote := global.Query.PollVote
+	if _, err := tx.PollVote.WithContext(ctx).Where(pollVote.MsgID.In(ids...)).Delete(); err != nil {
+		if err := tx.Rollback(); err != nil {
+			global.Logger.Errorf("事务回滚失败 %s", err.Error())
+		}
+		global.Logger.Errorf("删除投票记录失败 %s", err)
+		return err
+	}
+	if err := tx.Commit(); err != nil {
+		global.Logger.Errorf("事务提交失败 %s", err.Error())
+		return err
+	}
+	return nil
+}
+
+// refreshRoomLastMsg 最后一条消息被删除时，会话预览改为房间内最新的未删除消息
+func refreshRoomLastMsg(roomId int64, deletedIds []int64) {
+	ctx := context.Background()
+	message := global.Query.Message
+	lastMsgId := int64(0)
+	lastMsg, err := message.WithContext(ctx).Where(message.RoomID.Eq(roomId), message.DeleteStatus.Eq(pkgEnum.NORMAL)).Order(message.ID.Desc()).First()
+	if err != nil && !errors.Is(err, gorm.ErrRecordNotFound) {
+		global.Logger.Errorf("查询房间最后一条消息失败 %s", err)
+		return
+	}
+	if lastMsg != nil {
+		lastMsgId = lastMsg.ID
+	}
+	contact := global.Query.Contact
+	if _, err := contact.WithContext(ctx).Where(contact.RoomID.Eq(roomId), contact.LastMsgID.In(deletedIds...)).Update(contact.LastMsgID, lastMsgId); err != nil {
+		global.Logger.Errorf("更新会话最后一条消息失败 %s", err)
+	}
+	room := global.Query.Room
+	result, err := room.WithContext(ctx).Where(room.ID.Eq(roomId), room.LastMsgID.In(deletedIds...)).Update(room.LastMsgID, lastMsgId)
+	if err != nil {
+		global.Logger.Errorf("更新房间最后一条消息失败 %s", err)
+		return
+	}
+	if result.RowsAffected > 0 {
+		redisCache.RemoveRoomCache(model.Room{ID: roomId})
+	}
+}
@@ -0,0 +1,23 @@
+//go:build integration
+
+package service
+
+import "testing"
+
+func TestMsgTtlText(t *testing.T) {
+	tests := []struct {
+		ttl  int32
+		want string
+	}{
+		{ttl: 3600, want: "1小时"},
+		{ttl: 2 * 3600, want: "2小时"},
+		{ttl: 36 * 3600, want: "36小时"},
+		{ttl: 24 * 3600, want: "1天"},
+		{ttl: 7 * 24 * 3600, want: "7天"},
+	}
+	for _, tt := range tests {
+		if got := msgTtlText(tt.ttl); got != tt.want {
+			t.Errorf("msgTtlText(%d) = %q, want %q", tt.ttl, got, tt.want)
+		}
+	}
+}
@@ -0,0 +1,314 @@
+package service
+
+import (
+	"DiTing-Go/dal/model"
+	"DiTing-Go/domain/enum"
+	"DiTing-Go/domain/vo/req"
+	domainResp "DiTing-Go/domain/vo/resp"
+	"DiTing-Go/global"
+	pkgEnum "DiTing-Go/pkg/domain/enum"
+	"DiTing-Go/pkg/domain/vo/resp"
+	"context"
+	"github.com/go-redis/redis"
+	"github.com/pkg/errors"
+	"gorm.io/gorm"
+	"strconv"
+	"time"
+)
+
+// ScheduleMsgService 保存定时消息，到期后由 ScheduledMsgJob 按普通消息发送
+func ScheduleMsgService(uid int64, msgReq req.MessageReq) (resp.ResponseData, error) {
+	ctx := context.Background()
+	sendAt := time.UnixMilli(msgReq.SendAt)
+	if sendAt.After(time.Now().Add(enum.ScheduledMsgMaxAhead)) {
+		return resp.ErrorResponseData("定时时间过远"), errors.New("Business Error")
+	}
+	scheduledMsg := global.Query.ScheduledMessage
+	scheduledMsgQ := scheduledMsg.WithContext(ctx)
+	// 重试请求直接返回已经保存的定时消息
+	if msgReq.ClientMsgId != "" {
+		existMsg, err := scheduledMsgQ.Where(scheduledMsg.UID.Eq(uid), scheduledMsg.ClientMsgID.Eq(msgReq.ClientMsgId)).First()
+		if err == nil {
+			// 同一客户端消息ID只能对应一个房间
+			if existMsg.RoomID != msgReq.RoomId {
+				return resp.ErrorResponseData("客户端消息ID重复"), errors.New("Business Error")
+			}
+			return resp.SuccessResponseData(buildScheduledMsgResp(existMsg)), nil
+		}
+		if !errors.Is(err, gorm.ErrRecordNotFound) {
+			global.Logger.Errorf("查询定时消息失败 %s", err)
+			return resp.ErrorResponseData("消息发送失败"), errors.New("Business Error")
+		}
+	}
+
+	if errResp, err := checkScheduledMsgRoom(uid, msgReq.RoomId); err != nil {
+		return errResp, err
+	}
+	count, err := scheduledMsgQ.Where(scheduledMsg.UID.Eq(uid), scheduledMsg.Status.Eq(enum.ScheduledPending)).Count()
+	if err != nil {
+		global.Logger.Errorf("查询定时消息失败 %s", err)
+		return resp.ErrorResponseData("消息发送失败"), errors.New("Business Error")
+	}
+	if count >= enum.ScheduledMsgLimit {
+		return resp.ErrorResponseData("待发送的定时消息过多"), errors.New("Business Error")
+	}
+
+	newScheduledMsg := model.ScheduledMessage{
+		UID:         uid,
+		RoomID:      msgReq.RoomId,
+		Type:        msgReq.MsgType,
+		Content:     msgReq.Body.Content,
+		ReplyMsgID:  msgReq.Body.ReplyMsgId,
+		ClientMsgID: msgReq.ClientMsgId,
+		SendAt:      sendAt,
+		Status:      enum.ScheduledPending,
+	}
+	if err := scheduledMsgQ.Create(&newScheduledMsg); err != nil {
+		global.Logger.Errorf("保存定时消息失败 %s", err)
+		return resp.ErrorResponseData("消息发送失败"), errors.New("Business Error")
+	}
+	if err := addScheduledMsg(&newScheduledMsg); err != nil {
+		// 没有进入调度队列的定时消息不会被发送，直接删除
+		if _, err := scheduledMsgQ.Where(scheduledMsg.ID.Eq(newScheduledMsg.ID)).Delete(); err != nil {
+			global.Logger.Errorf("删除定时消息失败 %s", err)
+		}
+		return resp.ErrorResponseData("消息发送失败"), errors.New("Business Error")
+	}
+	return resp.SuccessResponseData(buildScheduledMsgResp(&newScheduledMsg)), nil
+}
+
+// checkScheduledMsgRoom 校验用户仍能在房间中发送消息
+func checkScheduledMsgRoom(uid, roomId int64) (resp.ResponseData, error) {
+	isMember, err := IsRoomMember(uid, roomId)
+	if err != nil {
+		return resp.ErrorResponseData("消息发送失败"), errors.New("Business Error")
+	}
+	if !isMember {
+		return resp.ErrorResponseData("无权向该会话发送消息"), errors.New("Business Error")
+	}
+	isBlocked, err := IsRoomBlocked(uid, roomId)
+	if err != nil {
+		return resp.ErrorResponseData("消息发送失败"), errors.New("Business Error")
+	}
+	if isBlocked {
+		return resp.ErrorResponseDataWithCode(pkgEnum.ERROR_USER_BLOCKED, nil), errors.New("Business Error")
+	}
+	return resp.ResponseData{}, nil
+}
+
+// GetScheduledMsgListService 获取待发送的定时消息，按计划时间排序
+func GetScheduledMsgListService(uid int64) (resp.ResponseData, error) {
+	scheduledMsg := global.Query.ScheduledMessage
+	scheduledMsgQ := scheduledMsg.WithContext(context.Background())
+	scheduledMsgList, err := scheduledMsgQ.Where(scheduledMsg.UID.Eq(uid), scheduledMsg.Status.Eq(enum.ScheduledPending)).Order(scheduledMsg.SendAt).Find()
+	if err != nil {
+		global.Logger.Errorf("查询定时消息失败 %s", err)
+		return resp.ErrorResponseData("系统繁忙，请稍后再试~"), errors.New("Business Error")
+	}
+	scheduledMsgRespList := make([]domainResp.ScheduledMsgResp, 0, len(scheduledMsgList))
+	for _, scheduledMsgR := range scheduledMsgList {
+		scheduledMsgRespList = append(scheduledMsgRespList, buildScheduledMsgResp(scheduledMsgR))
+	}
+	return resp.SuccessResponseData(scheduledMsgRespList), nil
+}
+
+// CancelScheduledMsgService 取消待发送的定时消息
+func CancelScheduledMsgService(uid int64, cancelReq req.CancelScheduledMsgReq) (resp.ResponseData, error) {
+	scheduledMsg := global.Query.ScheduledMessage
+	scheduledMsgQ := scheduledMsg.WithContext(context.Background())
+	result, err := scheduledMsgQ.Where(scheduledMsg.ID.Eq(cancelReq.Id), scheduledMsg.UID.Eq(uid), scheduledMsg.Status.Eq(enum.ScheduledPending)).Update(scheduledMsg.Status, enum.ScheduledCancelled)
+	if err != nil {
+		global.Logger.Errorf("取消定时消息失败 %s", err)
+		return resp.ErrorResponseData("系统繁忙，请稍后再试~"), errors.New("Business Error")
+	}
+	if result.RowsAffected == 0 {
+		return resp.ErrorResponseData("定时消息不存在或已发送"), errors.New("Business Error")
+	}
+	if err := global.Rdb.ZRem(enum.ScheduledMsgZSet, cancelReq.Id).Err(); err != nil {
+		global.Logger.Errorf("移除定时消息调度失败 %s", err)
+	}
+	return resp.SuccessResponseDataWithMsg("已取消"), nil
+}
+
+func buildScheduledMsgResp(scheduledMsg *model.ScheduledMessage) domainResp.ScheduledMsgResp {
+	return domainResp.ScheduledMsgResp{
+		Id:     scheduledMsg.ID,
+		RoomId: scheduledMsg.RoomID,
+		Type:   scheduledMsg.Type,
+		Body: domainResp.TextBody{
+			Content: scheduledMsg.Content,
+			Reply:   scheduledMsg.ReplyMsgID,
+		},
+		ClientMsgId: scheduledMsg.ClientMsgID,
+		SendAt:      scheduledMsg.SendAt.UnixMilli(),
+		Status:      scheduledMsg.Status,
+	}
+}
+
+// addScheduledMsg 将定时消息加入调度队列
+func addScheduledMsg(scheduledMsg *model.ScheduledMessage) error {
+	z := redis.Z{Score: float64(scheduledMsg.SendAt.UnixMilli()), Member: scheduledMsg.ID}
+	if err := global.Rdb.ZAdd(enum.ScheduledMsgZSet, z).Err(); err != nil {
+		global.Logger.Errorf("加入定时消息调度失败 %s", err)
+		return err
+	}
+	return nil
+}
+
+// ScheduledMsgJob 投递到期的定时消息
+// 启动时把数据库中待发送的消息重新加入调度队列，服务重启或 Redis 数据丢失后不会漏发
+func ScheduledMsgJob() {
+	loadScheduledMsg()
+	ticker := time.NewTicker(enum.ScheduledMsgInterval)
+	defer ticker.Stop()
+	for range ticker.C {
+		deliverDueMsg()
+	}
+}
+
+func loadScheduledMsg() {
+	scheduledMsg := global.Query.ScheduledMessage
+	scheduledMsgQ := scheduledMsg.WithContext(context.Background())
+	lastId := int64(0)
+	for {
+		scheduledMsgList, err := scheduledMsgQ.Where(scheduledMsg.ID.Gt(lastId), scheduledMsg.Status.Eq(enum.ScheduledPending)).Order(scheduledMsg.ID).Limit(enum.ScheduledMsgBatchSize).Find()
+		if err != nil {
+			global.Logger.Errorf("查询待发送的定时消息失败 %s", err)
+			return
+		}
+		for _, scheduledMsgR := range scheduledMsgList {
+			_ = addScheduledMsg(scheduledMsgR)
+			lastId = scheduledMsgR.ID
+		}
+		if len(scheduledMsgList) < enum.ScheduledMsgBatchSize {
+			return
+		}
+	}
+}
+
+func deliverDueMsg() {
+	ids, err := global.Rdb.ZRangeByScore(enum.ScheduledMsgZSet, redis.ZRangeBy{
+		Min:   "-inf",
+		Max:   strconv.FormatInt(time.Now().UnixMilli(), 10),
+		Count: enum.ScheduledMsgBatchSize,
+	}).Result()
+	if err != nil {
+		global.Logger.Errorf("查询到期的定时消息失败 %s", err)
+		return
+	}
+	for _, idStr := range ids {
+		// 多实例部署时只有移除成功的实例负责投递
+		removed, err := global.Rdb.ZRem(enum.ScheduledMsgZSet, idStr).Result()
+		if err != nil || removed == 0 {
+			continue
+		}
+		id, err := strconv.ParseInt(idStr, 10, 64)
+		if err != nil {
+			continue
+		}
+		deliverScheduledMsg(id)
+	}
+}
+
+// retryScheduledMsg 投递遇到临时错误时重新加入调度队列，稍后再次投递
+func retryScheduledMsg(id int64) {
+	z := redis.Z{Score: float64(time.Now().Add(enum.ScheduledMsgRetryDelay).UnixMilli()), Member: id}
+	if err := global.Rdb.ZAdd(enum.ScheduledMsgZSet, z).Err(); err != nil {
+		global.Logger.Errorf("加入定时消息调度失败 %s", err)
+	}
+}
+
+// deliverScheduledMsg 按普通消息发送定时消息，发送失败时标记为失败，数据库临时错误时重新调度
+func deliverScheduledMsg(id int64) {
+	ctx := context.Background()
+	scheduledMsg := global.Query.ScheduledMessage
+	scheduledMsgR, err := scheduledMsg.WithContext(ctx).Where(scheduledMsg.ID.Eq(id)).First()
+	if err != nil {
+		if !errors.Is(err, gorm.ErrRecordNotFound) {
+			global.Logger.Errorf("查询定时消息失败 %s", err)
+			retryScheduledMsg(id)
+		}
+		return
+	}
+	if scheduledMsgR.Status != enum.ScheduledPending {
+		return
+	}
+	// 预约后可能已退出房间或被拉黑，查询失败时稍后重试
+	canSend, err := canSendScheduledMsg(scheduledMsgR.UID, scheduledMsgR.RoomID)
+	if err != nil {
+		retryScheduledMsg(id)
+		return
+	}
+	if !canSend {
+		failScheduledMsg(id)
+		return
+	}
+	// 客户端消息ID已被直接发送的消息占用，重试也无法成功
+	if scheduledMsgR.ClientMsgID != "" {
+		existMsg, err := getMessageByClientMsgId(scheduledMsgR.UID, scheduledMsgR.ClientMsgID)
+		if err != nil {
+			retryScheduledMsg(id)
+			return
+		}
+		if existMsg != nil {
+			failScheduledMsg(id)
+			return
+		}
+	}
+
+	msg := model.Message{
+		RoomID:      scheduledMsgR.RoomID,
+		FromUID:     scheduledMsgR.UID,
+		Type:        scheduledMsgR.Type,
+		Content:     scheduledMsgR.Content,
+		ReplyMsgID:  scheduledMsgR.ReplyMsgID,
+		ClientMsgID: scheduledMsgR.ClientMsgID,
+		Extra:       "{}",
+	}
+	tx := global.Query.Begin()
+	if err := SendTextMsgTx(tx, &msg); err != nil {
+		if err := tx.Rollback(); err != nil {
+			global.Logger.Errorf("事务回滚失败 %s", err.Error())
+		}
+		// 永久性错误已在上面排除，这里多为数据库的临时错误
+		// 并发发送占用了客户端消息ID时，重试会在上面的检查中失败
+		retryScheduledMsg(id)
+		return
+	}
+	// 状态作为更新条件，投递期间被取消的消息不再发送
+	result, err := tx.ScheduledMessage.WithContext(ctx).Where(scheduledMsg.ID.Eq(id), scheduledMsg.Status.Eq(enum.ScheduledPending)).UpdateSimple(scheduledMsg.Status.Value(enum.ScheduledSent), scheduledMsg.MsgID.Value(msg.ID))
+	if err != nil || result.RowsAffected == 0 {
+		if err := tx.Rollback(); err != nil {
+			global.Logger.Errorf("事务回滚失败 %s", err.Error())
+		}
+		if err != nil {
+			global.Logger.Errorf("更新定时消息失败 %s", err)
+			retryScheduledMsg(id)
+		}
+		return
+	}
+	if err := tx.Commit(); err != nil {
+		global.Logger.Errorf("事务提交失败 %s", err.Error())
+		retryScheduledMsg(id)
+	}
+}
+
+// canSendScheduledMsg 用户是否仍可向房间发送消息，err 不为空表示查询失败
+func canSendScheduledMsg(uid, roomId int64) (bool, error) {
+	isMember, err := IsRoomMember(uid, roomId)
+	if err != nil || !isMember {
+		return false, err
+	}
+	isBlocked, err := IsRoomBlocked(uid, roomId)
+	if err != nil {
+		return false, err
+	}
+	return !isBlocked, nil
+}
+
+func failScheduledMsg(id int64) {
+	scheduledMsg := global.Query.ScheduledMessage
+	if _, err := scheduledMsg.WithContext(context.Background()).Where(scheduledMsg.ID.Eq(id), scheduledMsg.Status.Eq(enum.ScheduledPending)).Update(scheduledMsg.Status, enum.ScheduledFailed); err != nil {
+		global.Logger.Errorf("更新定时消息失败 %s", err)
+	}
+}
@@ -0,0 +1,142 @@
+package service
+
+import (
+	"DiTing-Go/domain/dto"
+	domainEnum "DiTing-Go/domain/enum"
+	"DiTing-Go/domain/vo/req"
+	"DiTing-Go/domain/vo/resp"
+	"DiTing-Go/global"
+	pkgResp "DiTing-Go/pkg/domain/vo/resp"
+	"DiTing-Go/pkg/utils"
+	"DiTing-Go/utils/jsonUtils"
+	"fmt"
+	"github.com/go-redis/redis"
+	"github.com/goccy/go-json"
+	"github.com/pkg/errors"
+	"time"
+)
+
+// createSession 登录成功后创建会话并签发令牌，返回会话ID
+func createSession(uid int64) (string, resp.RefreshTokenResp, error) {
+	sid, err := utils.RandomToken(16)
+	if err != nil {
+		global.Logger.Errorf("生成会话ID失败 %v", err)
+		return "", resp.RefreshTokenResp{}, err
+	}
+	session := dto.SessionDto{
+		Uid:        uid,
+		Sid:        sid,
+		CreateTime: time.Now(),
+	}
+	tokenResp, err := issueTokens(&session)
+	return sid, tokenResp, err
+}
+
+// issueTokens 为会话签发新的访问令牌和刷新令牌，旧的刷新令牌随之失效
+func issueTokens(session *dto.SessionDto) (resp.RefreshTokenResp, error) {
+	refreshToken, err := utils.RandomToken(32)
+	if err != nil {
+		global.Logger.Errorf("生成刷新令牌失败 %v", err)
+		return resp.RefreshTokenResp{}, err
+	}
+	session.RefreshHash = utils.HashToken(refreshToken)
+	sessionByte, err := json.Marshal(session)
+	if err != nil {
+		global.Logger.Errorf("json序列化失败 %v", err)
+		return resp.RefreshTokenResp{}, err
+	}
+	expire := utils.GetRefreshTokenExpire()
+	userSessionsKey := fmt.Sprintf(domainEnum.TokenUserSessions, session.Uid)
+	pipe := global.Rdb.TxPipeline()
+	pipe.Set(fmt.Sprintf(domainEnum.TokenSession, session.Sid), sessionByte, expire)
+	pipe.Set(fmt.Sprintf(domainEnum.TokenRefresh, session.RefreshHash), session.Sid, expire)
+	pipe.SAdd(userSessionsKey, session.Sid)
+	pipe.Expire(userSessionsKey, expire)
+	if _, err := pipe.Exec(); err != nil {
+		global.Logger.Errorf("保存会话失败 %v", err)
+		return resp.RefreshTokenResp{}, err
+	}
+
+	token, err := utils.GenerateToken(session.Uid, session.Sid)
+	if err != nil {
+		return resp.RefreshTokenResp{}, err
+	}
+	return resp.RefreshTokenResp{
+		Token:        token,
+		RefreshToken: refreshToken,
+		ExpiresIn:    int64(utils.GetAccessTokenExpire() / time.Second),
+	}, nil
+}
+
+// getSession 查询会话，会话不存在时返回 redis.Nil
+func getSession(sid string) (*dto.SessionDto, error) {
+	session := dto.SessionDto{}
+	if err := utils.GetString(fmt.Sprintf(domainEnum.TokenSession, sid), &session); err != nil {
+		return nil, err
+	}
+	return &session, nil
+}
+
+// revokeSession 吊销会话，该会话签发的令牌立即失效，并断开该会话的websocket连接
+func revokeSession(uid int64, sid string) error {
+	pipe := global.Rdb.TxPipeline()
+	pipe.Del(fmt.Sprintf(domainEnum.TokenSession, sid))
+	pipe.SRem(fmt.Sprintf(domainEnum.TokenUserSessions, uid), sid)
+	if _, err := pipe.Exec(); err != nil {
+		global.Logger.Errorf("吊销会话失败 %v", err)
+		return err
+	}
+	offlineDevice(sid)
+	if err := jsonUtils.SendMsgSync(domainEnum.SessionRevokedTopic, dto.RevokeSessionDto{Uid: uid, Sid: sid}); err != nil {
+		global.Logger.Errorf("发送会话吊销事件失败 %v", err)
+	}
+	return nil
+}
+
+// RefreshTokenService 使用刷新令牌换取新的令牌，刷新令牌只能使用一次
+func RefreshTokenService(refreshReq req.RefreshTokenReq) (pkgResp.ResponseData, error) {
+	refreshHash := utils.HashToken(refreshReq.RefreshToken)
+	sid, err := global.Rdb.Get(fmt.Sprintf(domainEnum.TokenRefresh, refreshHash)).Result()
+	if errors.Is(err, redis.Nil) {
+		return pkgResp.ErrorResponseData("登录已失效，请重新登录"), errors.New("Business Error")
+	} else if err != nil {
+		global.Logger.Errorf("查询刷新令牌失败 %v", err)
+		return pkgResp.ErrorResponseData("系统繁忙，请稍后再试~"), errors.New("Business Error")
+	}
+
+	// 同一会话的并发刷新串行执行
+	lock, err := utils.GetLock(fmt.Sprintf(domainEnum.SessionLock, sid))
+	if err != nil {
+		return pkgResp.ErrorResponseData("系统繁忙，请稍后再试~"), errors.New("Business Error")
+	}
+	defer utils.ReleaseLock(lock)
+
+	session, err := getSession(sid)
+	if errors.Is(err, redis.Nil) {
+		return pkgResp.ErrorResponseData("登录已失效，请重新登录"), errors.New("Business Error")
+	} else if err != nil {
+		global.Logger.Errorf("查询会话失败 %v", err)
+		return pkgResp.ErrorResponseData("系统繁忙，请稍后再试~"), errors.New("Business Error")
+	}
+	// 已经轮换过的刷新令牌被再次使用，说明令牌可能泄露，吊销整个会话
+	if session.RefreshHash != refreshHash {
+		global.Logger.Warnf("刷新令牌被重复使用 uid:%d sid:%s", session.Uid, sid)
+		_ = revokeSession(session.Uid, sid)
+		return pkgResp.ErrorResponseData("登录已失效，请重新登录"), errors.New("Business Error")
+	}
+
+	tokenResp, err := issueTokens(session)
+	if err != nil {
+		return pkgResp.ErrorResponseData("系统繁忙，请稍后再试~"), errors.New("Business Error")
+	}
+	touchDevice(sid)
+	return pkgResp.SuccessResponseData(tokenResp), nil
+}
+
+// LogoutService 退出登录，吊销当前会话
+func LogoutService(uid int64, sid string) (pkgResp.ResponseData, error) {
+	if err := revokeSession(uid, sid); err != nil {
+		return pkgResp.ErrorResponseData("系统繁忙，请稍后再试~"), errors.New("Business Error")
+	}
+	return pkgResp.SuccessResponseDataWithMsg("退出成功"), nil
+}
@@ -0,0 +1,110 @@
+package service
+
+import (
+	"DiTing-Go/domain/enum"
+	"DiTing-Go/global"
+	"fmt"
+	"github.com/pkg/errors"
+	"github.com/spf13/viper"
+	"slices"
+	"time"
+)
+
+// uploadRule 不同消息类型的上传限制
+type uploadRule struct {
+	// 单个文件最大字节数
+	MaxSize int64
+	// 允许的MIME类型
+	AllowedTypes []string
+}
+
+// 消息类型对应的配置项名称
+var uploadRuleName = map[int32]string{
+	enum.ImgMessageType: "img",
+}
+
+// avatarUploadRule 头像上传的配置项名称
+const avatarUploadRule = "avatar"
+
+// 各配置项的默认上传限制
+var defaultUploadRules = map[string]uploadRule{
+	"img":            {MaxSize: enum.DefaultImgMaxSize, AllowedTypes: enum.DefaultImgAllowedTypes},
+	avatarUploadRule: {MaxSize: enum.DefaultAvatarMaxSize, AllowedTypes: enum.DefaultImgAllowedTypes},
+}
+
+var (
+	errUploadTooLarge    = errors.New("文件过大")
+	errUploadTypeDenied  = errors.New("不支持的文件类型")
+	errUploadQuotaExceed = errors.New("今日上传额度已用完")
+)
+
+// getUploadRule 获取消息类型对应的上传限制
+func getUploadRule(msgType int32) uploadRule {
+	return getUploadRuleByName(uploadRuleName[msgType])
+}
+
+// getUploadRuleByName 获取配置项对应的上传限制，未配置时使用默认值
+// 配置项 upload.<name>.maxSize upload.<name>.allowedTypes
+func getUploadRuleByName(name string) uploadRule {
+	rule, ok := defaultUploadRules[name]
+	if !ok {
+		return defaultUploadRules["img"]
+	}
+	if maxSize := viper.GetInt64(fmt.Sprintf("upload.%s.maxSize", name)); maxSize > 0 {
+		rule.MaxSize = maxSize
+	}
+	if allowedTypes := viper.GetStringSlice(fmt.Sprintf("upload.%s.allowedTypes", name)); len(allowedTypes) > 0 {
+		rule.AllowedTypes = allowedTypes
+	}
+	return rule
+}
+
+// check 校验文件大小和类型
+func (rule uploadRule) check(size int64, contentType string) error {
+	if size <= 0 || size > rule.MaxSize {
+		return errUploadTooLarge
+	}
+	if !slices.Contains(rule.AllowedTypes, contentType) {
+		return errUploadTypeDenied
+	}
+	return nil
+}
+
+// getDailyUploadQuota 每个用户每日上传额度，配置项 upload.dailyQuota
+func getDailyUploadQuota() int64 {
+	if quota := viper.GetInt64("upload.dailyQuota"); quota > 0 {
+		return quota
+	}
+	return enum.DefaultDailyUploadQuota
+}
+
+func uploadQuotaKey(uid int64, day time.Time) string {
+	return fmt.Sprintf(enum.UserUploadQuota, uid, day.Format("2006-01-02"))
+}
+
+// reserveUploadQuota 预占当日上传额度，超出额度时归还并返回错误
+func reserveUploadQuota(uid, size int64) error {
+	key := uploadQuotaKey(uid, time.Now())
+	used, err := global.Rdb.IncrBy(key, size).Result()
+	if err != nil {
+		global.Logger.Errorf("更新上传额度失败 %s", err)
+		return err
+	}
+	// 额度按天统计，保留到第二天结束即可
+	global.Rdb.Expire(key, 48*time.Hour)
+	if used > getDailyUploadQuota() {
+		global.Rdb.DecrBy(key, size)
+		return errUploadQuotaExceed
+	}
+	return nil
+}
+
+// adjustUploadQuota 按实际上传大小修正额度，delta 为负数时归还额度
+func adjustUploadQuota(uid int64, day time.Time, delta int64) {
+	if delta == 0 {
+		return
+	}
+	if err := global.Rdb.IncrBy(uploadQuotaKey(uid, day), delta).Err(); err != nil {
+		global.Logger.Errorf("更新上传额度失败 %s", err)
+	}
+}
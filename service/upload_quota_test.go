@@ -0,0 +1,32 @@
+//go:build integration
+
+package service
+
+import (
+	"testing"
+)
+
+func TestUploadRuleCheck(t *testing.T) {
+	rule := uploadRule{MaxSize: 100, AllowedTypes: []string{"image/png", "image/jpeg"}}
+	tests := []struct {
+		name        string
+		size        int64
+		contentType string
+		want        error
+	}{
+		{name: "允许的类型和大小", size: 100, contentType: "image/png", want: nil},
+		{name: "最小大小", size: 1, contentType: "image/jpeg", want: nil},
+		{name: "大小为0", size: 0, contentType: "image/png", want: errUploadTooLarge},
+		{name: "大小为负数", size: -1, contentType: "image/png", want: errUploadTooLarge},
+		{name: "超过最大大小", size: 101, contentType: "image/png", want: errUploadTooLarge},
+		{name: "不允许的类型", size: 10, contentType: "application/pdf", want: errUploadTypeDenied},
+		{name: "类型为空", size: 10, contentType: "", want: errUploadTypeDenied},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := rule.check(tt.size, tt.contentType); got != tt.want {
+				t.Errorf("check(%d, %q) = %v, want %v", tt.size, tt.contentType, got, tt.want)
+			}
+		})
+	}
+}
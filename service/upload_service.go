@@ -4,14 +4,20 @@ import (
 	"DiTing-Go/dal/model"
 	"DiTing-Go/domain/dto"
 	"DiTing-Go/domain/enum"
+	"DiTing-Go/domain/vo/req"
 	voResp "DiTing-Go/domain/vo/resp"
 	"DiTing-Go/global"
+	pkgEnum "DiTing-Go/pkg/domain/enum"
 	"DiTing-Go/pkg/domain/vo/resp"
+	"DiTing-Go/utils/outbox"
 	"context"
 	"fmt"
 	"github.com/gin-gonic/gin"
 	"github.com/goccy/go-json"
 	"github.com/minio/minio-go/v7"
+	"github.com/pkg/errors"
+	"gorm.io/gorm"
+	"net/http"
 	"strconv"
 	"time"
 )
@@ -22,6 +28,8 @@ import (
 //	@Produce	json
 //	@Param		roomId	query		int64				true	"房间ID"
 //	@Param		fileName	query		string				true	"文件名"
+//	@Param		contentType	query		string				true	"文件类型"
+//	@Param		size	query		int64				true	"文件大小"
 //	@Success	200	{object}	resp.ResponseData	"成功"
 //	@Failure	500	{object}	resp.ResponseData	"内部错误"
 //	@Router		/api/file/getPreSigned [get]
@@ -44,7 +52,7 @@ func GetPreSigned(c *gin.Context) {
 	roomId, err := strconv.ParseInt(roomIdStr, 10, 64)
 	if err != nil {
 		// 记录错误并返回错误响应
-		global.Logger.Errorf("参数错误 %s", roomId)
+		global.Logger.Errorf("参数错误 %s", roomIdStr)
 		resp.ErrorResponse(c, "参数错误")
 		c.Abort()
 		return
@@ -59,6 +67,71 @@ func GetPreSigned(c *gin.Context) {
 		c.Abort()
 		return
 	}
+	// 获取请求中的 contentType 和 size 参数
+	contentType, found := c.GetQuery("contentType")
+	if !found {
+		global.Logger.Errorf("参数错误 %s", roomIdStr)
+		resp.ErrorResponse(c, "参数错误")
+		c.Abort()
+		return
+	}
+	size, err := strconv.ParseInt(c.Query("size"), 10, 64)
+	if err != nil {
+		global.Logger.Errorf("参数错误 %s", c.Query("size"))
+		resp.ErrorResponse(c, "参数错误")
+		c.Abort()
+		return
+	}
+	// 校验文件大小和类型
+	rule := getUploadRule(enum.ImgMessageType)
+	if err := rule.check(size, contentType); err != nil {
+		resp.ErrorResponse(c, err.Error())
+		c.Abort()
+		return
+	}
+	// 校验用户是否在房间中，不在房间的用户无法向该房间发送图片
+	isMember, err := IsRoomMember(uid, roomId)
+	if err != nil {
+		resp.ErrorResponse(c, "获取签名失败，请稍后再试")
+		c.Abort()
+		return
+	}
+	if !isMember {
+		resp.ErrorResponse(c, "无权向该会话发送消息")
+		c.Abort()
+		return
+	}
+	// 单聊存在拉黑关系时拒绝发送
+	isBlocked, err := IsRoomBlocked(uid, roomId)
+	if err != nil {
+		resp.ErrorResponse(c, "获取签名失败，请稍后再试")
+		c.Abort()
+		return
+	}
+	if isBlocked {
+		resp.ReturnErrorResponse(c, resp.ErrorResponseDataWithCode(pkgEnum.ERROR_USER_BLOCKED, nil))
+		c.Abort()
+		return
+	}
+	// 预占当日上传额度
+	quotaDay := time.Now()
+	if err := reserveUploadQuota(uid, size); err != nil {
+		if errors.Is(err, errUploadQuotaExceed) {
+			resp.ErrorResponse(c, err.Error())
+		} else {
+			resp.ErrorResponse(c, "获取签名失败，请稍后再试")
+		}
+		c.Abort()
+		return
+	}
+	// 签名或入库失败时归还预占的额度
+	signed := false
+	defer func() {
+		if !signed {
+			adjustUploadQuota(uid, quotaDay, -size)
+		}
+	}()
+
 	// 构造文件名：时间戳 + 用户ID + 文件名
 	// 按天创建桶
 	timeStr := time.Now().Format("2006-01-02")
@@ -67,7 +140,7 @@ func GetPreSigned(c *gin.Context) {
 	// 创建一个新的 POST 签名策略对象
 	policy := minio.NewPostPolicy()
 	// 设置目标存储桶的名称
-	if err := policy.SetBucket("diting"); err != nil {
+	if err := policy.SetBucket(enum.MinioBucket); err != nil {
 		global.Logger.Errorf("创建policy失败 %s", roomIdStr)
 		resp.ErrorResponse(c, "获取签名失败，请稍后再试")
 		c.Abort()
@@ -80,6 +153,20 @@ func GetPreSigned(c *gin.Context) {
 		c.Abort()
 		return
 	}
+	// 限制上传文件的类型
+	if err := policy.SetContentType(contentType); err != nil {
+		global.Logger.Errorf("创建policy失败 %s", roomIdStr)
+		resp.ErrorResponse(c, "获取签名失败，请稍后再试")
+		c.Abort()
+		return
+	}
+	// 限制上传文件的大小不超过声明的大小，额度按声明的大小预占
+	if err := policy.SetContentLengthRange(1, size); err != nil {
+		global.Logger.Errorf("创建policy失败 %s", roomIdStr)
+		resp.ErrorResponse(c, "获取签名失败，请稍后再试")
+		c.Abort()
+		return
+	}
 	// 设置策略的过期时间为1天
 	if err := policy.SetExpires(time.Now().UTC().AddDate(0, 0, 1)); err != nil {
 		global.Logger.Errorf("创建policy失败 %s", roomIdStr)
@@ -105,9 +192,9 @@ func GetPreSigned(c *gin.Context) {
 	tx := global.Query.Begin()
 	// 在事务上下文中操作消息表
 	messageTx := tx.Message.WithContext(ctx)
+	// 下载地址在消息入库后生成
 	base := dto.MessageBaseDto{
-		Url:  url.String(),
-		Size: -1, // 文件大小未知，初始化为 -1
+		Size: size, // 客户端声明的大小，上传完成后修正为实际大小
 		Name: fileName,
 	}
 	// 构造图片消息的额外信息
@@ -133,9 +220,19 @@ func GetPreSigned(c *gin.Context) {
 		FromUID:      uid,
 		RoomID:       roomId,
 		Content:      "[图片]",
-		DeleteStatus: 0,
+		DeleteStatus: pkgEnum.NORMAL,
 		Type:         3,
 		Extra:        string(jsonStr),
+		CreateTime:   time.Now(),
+	}
+	// 房间开启消息自毁时设置自毁时间
+	if err := stampMsgExpire(tx, &newMsg); err != nil {
+		if err := tx.Rollback(); err != nil {
+			global.Logger.Errorf("事务回滚失败 %s", err)
+		}
+		resp.ErrorResponse(c, "获取签名失败，请稍后再试")
+		c.Abort()
+		return
 	}
 	// 插入消息到数据库
 	if err := messageTx.Create(&newMsg); err != nil {
@@ -148,10 +245,242 @@ func GetPreSigned(c *gin.Context) {
 		c.Abort()
 		return
 	}
+	// 记录消息对文件的引用，消息自毁时据此判断能否删除文件
+	if err := saveObjectRefs(tx, enum.ObjectRefMessage, newMsg.ID, []string{fileName}); err != nil {
+		if err := tx.Rollback(); err != nil {
+			global.Logger.Errorf("事务回滚失败 %s", err)
+		}
+		resp.ErrorResponse(c, "获取签名失败，请稍后再试")
+		c.Abort()
+		return
+	}
 
+	// 回填下载地址，下载时校验房间成员身份
+	extra.MessageBaseDto.Url = fmt.Sprintf(enum.FileDownloadUrl, newMsg.ID)
+	jsonStr, err = json.Marshal(extra)
+	if err != nil {
+		if err := tx.Rollback(); err != nil {
+			global.Logger.Errorf("事务回滚失败 %s", err)
+		}
+		global.Logger.Errorf("json序列化失败 %s", err)
+		resp.ErrorResponse(c, "获取签名失败，请稍后再试")
+		c.Abort()
+		return
+	}
+	message := global.Query.Message
+	if _, err := messageTx.Where(message.ID.Eq(newMsg.ID)).Update(message.Extra, string(jsonStr)); err != nil {
+		if err := tx.Rollback(); err != nil {
+			global.Logger.Errorf("事务回滚失败 %s", err)
+		}
+		global.Logger.Errorf("数据库更新失败 %s", err)
+		resp.ErrorResponse(c, "获取签名失败，请稍后再试")
+		c.Abort()
+		return
+	}
+	newMsg.Extra = string(jsonStr)
 	// 发布新消息事件
-	global.Bus.Publish(enum.NewMessageEvent, newMsg)
+	if err := outbox.Save(tx, enum.NewMessageTopic, newMsg); err != nil {
+		if err := tx.Rollback(); err != nil {
+			global.Logger.Errorf("事务回滚失败 %s", err)
+		}
+		global.Logger.Errorf("写入新消息事件失败 %s", err)
+		resp.ErrorResponse(c, "获取签名失败，请稍后再试")
+		c.Abort()
+		return
+	}
+	if err := tx.Commit(); err != nil {
+		global.Logger.Errorf("事务提交失败 %s", err)
+		resp.ErrorResponse(c, "获取签名失败，请稍后再试")
+		c.Abort()
+		return
+	}
+	signed = true
 
 	// 返回成功响应
 	resp.SuccessResponse(c, preSignedResp)
 }
+
+// DownloadService 下载文件
+//
+//	@Summary	下载文件
+//	@Produce	json
+//	@Param		msgId	query		int64				true	"消息ID"
+//	@Param		index	query		int					false	"聊天记录中的消息下标"
+//	@Success	302	{string}	string				"重定向到临时下载链接"
+//	@Failure	500	{object}	resp.ResponseData	"内部错误"
+//	@Router		/api/file/download [get]
+func DownloadService(c *gin.Context) {
+	uid := c.GetInt64("uid")
+	downloadFileReq := req.DownloadFileReq{}
+	if err := c.ShouldBindQuery(&downloadFileReq); err != nil {
+		resp.ErrorResponse(c, "参数错误")
+		c.Abort()
+		return
+	}
+	ctx := context.Background()
+
+	// 查询消息
+	message := global.Query.Message
+	messageQ := message.WithContext(ctx)
+	messageR, err := messageQ.Where(message.ID.Eq(downloadFileReq.MsgId), message.DeleteStatus.Eq(pkgEnum.NORMAL)).First()
+	if err != nil {
+		if !errors.Is(err, gorm.ErrRecordNotFound) {
+			global.Logger.Errorf("查询消息失败 %s", err)
+		}
+		resp.ErrorResponse(c, "文件不存在")
+		c.Abort()
+		return
+	}
+	extraStr, err := pickDownloadExtra(messageR.Type, messageR.Extra, downloadFileReq.Index)
+	if err != nil {
+		if errors.Is(err, errFileNotFound) {
+			resp.ErrorResponse(c, "文件不存在")
+		} else {
+			resp.ErrorResponse(c, "系统繁忙，请稍后再试~")
+		}
+		c.Abort()
+		return
+	}
+
+	// 校验用户是否仍在房间中，退群或删除好友后无法再下载
+	isMember, err := IsRoomMember(uid, messageR.RoomID)
+	if err != nil {
+		resp.ErrorResponse(c, "系统繁忙，请稍后再试~")
+		c.Abort()
+		return
+	}
+	if !isMember {
+		resp.ErrorResponse(c, "无权限访问")
+		c.Abort()
+		return
+	}
+
+	url, err := presignedDownloadUrl(extraStr)
+	if err != nil {
+		resp.ErrorResponse(c, "系统繁忙，请稍后再试~")
+		c.Abort()
+		return
+	}
+	c.Redirect(http.StatusFound, url)
+}
+
+var errFileNotFound = errors.New("文件不存在")
+
+// pickDownloadExtra 取出可下载文件的扩展信息，聊天记录中的文件按下标取出快照
+func pickDownloadExtra(msgType int32, extraStr string, index *int) (string, error) {
+	if msgType == enum.ChatHistoryMessageType && index != nil {
+		chatHistory := dto.ChatHistoryDto{}
+		if err := json.Unmarshal([]byte(extraStr), &chatHistory); err != nil {
+			global.Logger.Errorf("json反序列化失败 %s", err)
+			return "", err
+		}
+		if *index >= len(chatHistory.Messages) || chatHistory.Messages[*index].Type != enum.ImgMessageType {
+			return "", errFileNotFound
+		}
+		return string(chatHistory.Messages[*index].Extra), nil
+	}
+	if msgType != enum.ImgMessageType {
+		return "", errFileNotFound
+	}
+	return extraStr, nil
+}
+
+// presignedDownloadUrl 根据文件消息的扩展信息签发临时下载链接
+func presignedDownloadUrl(extraStr string) (string, error) {
+	extra := dto.ImgMessageDto{}
+	if err := json.Unmarshal([]byte(extraStr), &extra); err != nil {
+		global.Logger.Errorf("json反序列化失败 %s", err)
+		return "", err
+	}
+	url, err := global.MinioClient.PresignedGetObject(context.Background(), enum.MinioBucket, extra.MessageBaseDto.Name, enum.FileDownloadExpire, nil)
+	if err != nil {
+		global.Logger.Errorf("签发下载链接失败 %s", err)
+		return "", err
+	}
+	return url.String(), nil
+}
+
+// CompleteUploadService 上传完成
+//
+//	@Summary	上传完成
+//	@Produce	json
+//	@Param		msgId	body		int64				true	"消息ID"
+//	@Success	200	{object}	resp.ResponseData	"成功"
+//	@Failure	500	{object}	resp.ResponseData	"内部错误"
+//	@Router		/api/file/complete [post]
+func CompleteUploadService(c *gin.Context) {
+	uid := c.GetInt64("uid")
+	completeUploadReq := req.CompleteUploadReq{}
+	if err := c.ShouldBind(&completeUploadReq); err != nil {
+		resp.ErrorResponse(c, "参数错误")
+		c.Abort()
+		return
+	}
+	ctx := context.Background()
+
+	// 只能确认自己上传的文件
+	message := global.Query.Message
+	messageQ := message.WithContext(ctx)
+	messageR, err := messageQ.Where(message.ID.Eq(completeUploadReq.MsgId), message.FromUID.Eq(uid), message.DeleteStatus.Eq(pkgEnum.NORMAL)).First()
+	if err != nil {
+		if !errors.Is(err, gorm.ErrRecordNotFound) {
+			global.Logger.Errorf("查询消息失败 %s", err)
+		}
+		resp.ErrorResponse(c, "文件不存在")
+		c.Abort()
+		return
+	}
+	extra := dto.ImgMessageDto{}
+	if err := json.Unmarshal([]byte(messageR.Extra), &extra); err != nil {
+		global.Logger.Errorf("json反序列化失败 %s", err)
+		resp.ErrorResponse(c, "系统繁忙，请稍后再试~")
+		c.Abort()
+		return
+	}
+
+	// 查询实际上传的文件
+	objectInfo, err := global.MinioClient.StatObject(ctx, enum.MinioBucket, extra.MessageBaseDto.Name, minio.StatObjectOptions{})
+	if err != nil {
+		global.Logger.Errorf("查询文件失败 %s", err)
+		resp.ErrorResponse(c, "文件未上传")
+		c.Abort()
+		return
+	}
+
+	// 实际文件不符合限制，删除文件和消息并归还额度
+	rule := getUploadRule(messageR.Type)
+	if err := rule.check(objectInfo.Size, objectInfo.ContentType); err != nil {
+		if err := global.MinioClient.RemoveObject(ctx, enum.MinioBucket, extra.MessageBaseDto.Name, minio.RemoveObjectOptions{}); err != nil {
+			global.Logger.Errorf("删除文件失败 %s", err)
+		}
+		if _, err := messageQ.Where(message.ID.Eq(messageR.ID)).Update(message.DeleteStatus, pkgEnum.DELETED); err != nil {
+			global.Logger.Errorf("删除消息失败 %s", err)
+		}
+		objectRef := global.Query.ObjectRef
+		if _, err := objectRef.WithContext(ctx).Where(objectRef.RefType.Eq(enum.ObjectRefMessage), objectRef.RefID.Eq(messageR.ID)).Delete(); err != nil {
+			global.Logger.Errorf("删除文件引用失败 %s", err)
+		}
+		adjustUploadQuota(uid, messageR.CreateTime, -extra.MessageBaseDto.Size)
+		resp.ErrorResponse(c, err.Error())
+		c.Abort()
+		return
+	}
+
+	// 按实际大小修正额度和消息
+	adjustUploadQuota(uid, messageR.CreateTime, objectInfo.Size-extra.MessageBaseDto.Size)
+	extra.MessageBaseDto.Size = objectInfo.Size
+	jsonStr, err := json.Marshal(extra)
+	if err != nil {
+		global.Logger.Errorf("json序列化失败 %s", err)
+		resp.ErrorResponse(c, "系统繁忙，请稍后再试~")
+		c.Abort()
+		return
+	}
+	if _, err := messageQ.Where(message.ID.Eq(messageR.ID)).Update(message.Extra, string(jsonStr)); err != nil {
+		global.Logger.Errorf("更新消息失败 %s", err)
+		resp.ErrorResponse(c, "系统繁忙，请稍后再试~")
+		c.Abort()
+		return
+	}
+	resp.SuccessResponseWithMsg(c, "success")
+}
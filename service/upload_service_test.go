@@ -0,0 +1,44 @@
+//go:build integration
+
+package service
+
+import (
+	"DiTing-Go/domain/enum"
+	"errors"
+	"testing"
+)
+
+func TestPickDownloadExtra(t *testing.T) {
+	imgExtra := `{"message_base_dto":{"name":"a.png"}}`
+	chatHistory := `{"room_id":1,"messages":[` +
+		`{"msg_id":1,"type":3,"extra":{"message_base_dto":{"name":"a.png"}}},` +
+		`{"msg_id":2,"type":1,"content":"hi","extra":{}}]}`
+	index := func(i int) *int { return &i }
+	tests := []struct {
+		name    string
+		msgType int32
+		extra   string
+		index   *int
+		want    string
+		wantErr error
+	}{
+		{name: "图片消息", msgType: enum.ImgMessageType, extra: imgExtra, want: imgExtra},
+		{name: "图片消息忽略下标", msgType: enum.ImgMessageType, extra: imgExtra, index: index(3), want: imgExtra},
+		{name: "聊天记录中的图片", msgType: enum.ChatHistoryMessageType, extra: chatHistory, index: index(0), want: imgExtra},
+		{name: "聊天记录中的文本", msgType: enum.ChatHistoryMessageType, extra: chatHistory, index: index(1), wantErr: errFileNotFound},
+		{name: "聊天记录下标越界", msgType: enum.ChatHistoryMessageType, extra: chatHistory, index: index(2), wantErr: errFileNotFound},
+		{name: "聊天记录没有下标", msgType: enum.ChatHistoryMessageType, extra: chatHistory, wantErr: errFileNotFound},
+		{name: "文本消息", msgType: enum.TextMessageType, extra: `{}`, wantErr: errFileNotFound},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := pickDownloadExtra(tt.msgType, tt.extra, tt.index)
+			if !errors.Is(err, tt.wantErr) {
+				t.Fatalf("pickDownloadExtra() error = %v, want %v", err, tt.wantErr)
+			}
+			if got != tt.want {
+				t.Errorf("pickDownloadExtra() = %s, want %s", got, tt.want)
+			}
+		})
+	}
+}
@@ -0,0 +1,136 @@
+package service
+
+import (
+	"DiTing-Go/dal"
+	"DiTing-Go/dal/model"
+	domainEnum "DiTing-Go/domain/enum"
+	"DiTing-Go/domain/vo/req"
+	"DiTing-Go/global"
+	pkgEnum "DiTing-Go/pkg/domain/enum"
+	pkgReq "DiTing-Go/pkg/domain/vo/req"
+	pkgResp "DiTing-Go/pkg/domain/vo/resp"
+	"DiTing-Go/pkg/utils"
+	"DiTing-Go/service/adapter"
+	"context"
+	"github.com/go-redsync/redsync/v4"
+	"github.com/pkg/errors"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// likeEscaper 转义 like 中的通配符
+var likeEscaper = strings.NewReplacer(`\`, `\\`, `%`, `\%`, `_`, `\_`)
+
+// GetUserInfoByNameService 搜索用户
+// 关键字为数字时按uid精确查找，昵称完全一致的用户排在首页最前面，其余按昵称或拼音模糊匹配分页返回
+// 关闭了昵称搜索的用户只能通过uid找到，存在拉黑关系的用户互相搜索不到
+func GetUserInfoByNameService(uid int64, searchReq req.GetUserInfoByNameReq) (pkgResp.ResponseData, error) {
+	ctx := context.Background()
+	keyword := strings.TrimSpace(searchReq.Name)
+	if keyword == "" {
+		return pkgResp.ErrorResponseData("搜索内容不能为空"), errors.New("Business Error")
+	}
+	pageReq := pkgReq.PageReq{
+		Cursor:   searchReq.Cursor,
+		PageSize: searchReq.PageSize,
+	}
+	if pageReq.PageSize <= 0 {
+		pageReq.PageSize = domainEnum.UserSearchDefaultPageSize
+	}
+	blockCondition := "id not in (select target_uid from user_block where uid = ? and delete_status = ?) and id not in (select uid from user_block where target_uid = ? and delete_status = ?)"
+	blockArgs := []interface{}{uid, pkgEnum.NORMAL, uid, pkgEnum.NORMAL}
+
+	// 精确匹配的用户，每页都从模糊匹配中排除，只在首页展示
+	exactList := make([]model.User, 0)
+	exactCondition := []interface{}{"id != ? and " + blockCondition + " and (name = ? and discoverable = ?", uid}
+	exactCondition = append(exactCondition, blockArgs...)
+	exactCondition = append(exactCondition, keyword, domainEnum.Discoverable)
+	if searchUid, err := strconv.ParseInt(keyword, 10, 64); err == nil {
+		exactCondition[0] = exactCondition[0].(string) + " or id = ?"
+		exactCondition = append(exactCondition, searchUid)
+	}
+	exactCondition[0] = exactCondition[0].(string) + ")"
+	if err := dal.DB.Where(exactCondition[0], exactCondition[1:]...).Order("id desc").Find(&exactList).Error; err != nil {
+		global.Logger.Errorf("查询用户数据失败: %v", err)
+		return pkgResp.ErrorResponseData("系统繁忙，请稍后再试~"), errors.New("Business Error")
+	}
+	exactUids := []int64{uid}
+	for _, exact := range exactList {
+		exactUids = append(exactUids, exact.ID)
+	}
+
+	fuzzyList := make([]model.User, 0)
+	like := "%" + likeEscaper.Replace(strings.ToLower(keyword)) + "%"
+	condition := []interface{}{"id not in ? and discoverable = ? and " + blockCondition + " and (name like ? or name_pinyin like ?)", exactUids, domainEnum.Discoverable}
+	condition = append(condition, blockArgs...)
+	condition = append(condition, like, like)
+	pageResp, err := utils.Paginate(dal.DB, pageReq, &fuzzyList, "id", false, condition...)
+	if err != nil {
+		global.Logger.Errorf("查询用户数据失败: %v", err)
+		return pkgResp.ErrorResponseData("系统繁忙，请稍后再试~"), errors.New("Business Error")
+	}
+
+	userRList := make([]*model.User, 0, len(exactList)+len(fuzzyList))
+	if pageReq.Cursor == nil || *pageReq.Cursor == "" {
+		for i := range exactList {
+			userRList = append(userRList, &exactList[i])
+		}
+	}
+	for i := range fuzzyList {
+		userRList = append(userRList, &fuzzyList[i])
+	}
+	uidList := make([]int64, 0, len(userRList))
+	for _, userR := range userRList {
+		uidList = append(uidList, userR.ID)
+	}
+	//	搜索待处理的好友申请
+	userApply := global.Query.UserApply
+	userApplyQ := userApply.WithContext(ctx)
+	applyList, err := userApplyQ.Where(userApply.UID.Eq(uid), userApply.TargetID.In(uidList...), userApply.Status.Eq(domainEnum.ApplyWaiting)).Find()
+	if err != nil {
+		global.Logger.Errorf("查询好友关系失败: %v", err)
+		return pkgResp.ErrorResponseData("系统繁忙，请稍后再试~"), errors.New("Business Error")
+	}
+	//	查询好友关系
+	userFriend := global.Query.UserFriend
+	userFriendQ := userFriend.WithContext(ctx)
+	friendList, err := userFriendQ.Where(userFriend.UID.Eq(uid), userFriend.FriendUID.In(uidList...), userFriend.DeleteStatus.Eq(pkgEnum.NORMAL)).Find()
+	if err != nil {
+		global.Logger.Errorf("查询好友关系失败: %v", err)
+		return pkgResp.ErrorResponseData("系统繁忙，请稍后再试~"), errors.New("Business Error")
+	}
+	pageResp.Data = adapter.BuildUserInfoByNameResp(userRList, applyList, friendList)
+	return pkgResp.SuccessResponseData(pageResp), nil
+}
+
+// BackfillNamePinyinJob 为历史用户补全昵称拼音，多实例部署时只有一个实例执行
+func BackfillNamePinyinJob() {
+	mutex := global.RedSync.NewMutex(domainEnum.PinyinBackfillLock, redsync.WithExpiry(time.Hour))
+	if err := mutex.TryLock(); err != nil {
+		return
+	}
+	defer mutex.Unlock()
+
+	ctx := context.Background()
+	user := global.Query.User
+	userQ := user.WithContext(ctx)
+	lastId := int64(0)
+	for {
+		userList, err := userQ.Select(user.ID, user.Name).Where(user.ID.Gt(lastId), user.NamePinyin.Eq("")).Order(user.ID).Limit(domainEnum.PinyinBackfillBatchSize).Find()
+		if err != nil {
+			global.Logger.Errorf("查询待补全拼音的用户失败 %s", err)
+			return
+		}
+		for _, userR := range userList {
+			// 只在拼音仍为空时更新，避免覆盖期间改名写入的新拼音
+			if _, err := userQ.Where(user.ID.Eq(userR.ID), user.Name.Eq(userR.Name), user.NamePinyin.Eq("")).UpdateSimple(user.NamePinyin.Value(utils.NamePinyin(userR.Name))); err != nil {
+				global.Logger.Errorf("补全用户拼音失败 %s", err)
+			}
+			lastId = userR.ID
+		}
+		if len(userList) < domainEnum.PinyinBackfillBatchSize {
+			return
+		}
+	}
+}
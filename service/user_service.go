@@ -7,16 +7,18 @@ import (
 	"DiTing-Go/domain/vo/req"
 	"DiTing-Go/domain/vo/resp"
 	"DiTing-Go/global"
+	pkgEnum "DiTing-Go/pkg/domain/enum"
 	pkgResp "DiTing-Go/pkg/domain/vo/resp"
 	_ "DiTing-Go/pkg/setting"
 	"DiTing-Go/pkg/utils"
-	"DiTing-Go/service/adapter"
+	"DiTing-Go/utils/jsonUtils"
+	"DiTing-Go/utils/redisCache"
 	"context"
 	"fmt"
-	"github.com/apache/rocketmq-client-go/v2/primitive"
-	"github.com/goccy/go-json"
 	"github.com/pkg/errors"
 	"gorm.io/gorm"
+	"math"
+	"time"
 )
 
 var q *query.Query = global.Query
@@ -30,6 +32,12 @@ func RegisterService(userReq req.UserRegisterReq) (pkgResp.ResponseData, error)
 	user := global.Query.User
 	// 将上下文添加到用户查询对象中
 	userQ := user.WithContext(ctx)
+	// 与修改昵称共用锁，保证用户名唯一
+	lock, err := utils.GetLock(fmt.Sprintf(domainEnum.UserNameLock, userReq.Username))
+	if err != nil {
+		return pkgResp.ErrorResponseData("系统繁忙，请稍后再试~"), errors.New("Business Error")
+	}
+	defer utils.ReleaseLock(lock)
 	// 定义一个函数，该函数将在数据库中查找与请求中的用户名匹配的用户
 	fun := func() (interface{}, error) {
 		return userQ.Where(user.Name.Eq(userReq.Username)).First()
@@ -39,7 +47,7 @@ func RegisterService(userReq req.UserRegisterReq) (pkgResp.ResponseData, error)
 	// 生成一个缓存键，该键基于请求中的用户名
 	key := fmt.Sprintf(domainEnum.UserCacheByName, userReq.Username)
 	// 尝试从缓存或数据库中获取用户数据
-	err := utils.GetData(key, &userR, fun)
+	err = utils.GetData(key, &userR, fun)
 	// 如果没有错误，说明找到了匹配的用户，因此返回一个错误响应
 	if err == nil {
 		return pkgResp.ErrorResponseData("用户名已存在"), errors.New("Business Error")
@@ -49,11 +57,19 @@ func RegisterService(userReq req.UserRegisterReq) (pkgResp.ResponseData, error)
 		global.Logger.Errorf("查询数据失败: %v", err)
 		return pkgResp.ErrorResponseData("系统繁忙，请稍后再试~"), errors.New("Business Error")
 	}
+	// 密码只保存 bcrypt 哈希
+	hashedPassword, err := utils.HashPassword(userReq.Password)
+	if err != nil {
+		global.Logger.Errorf("密码哈希失败: %v", err)
+		return pkgResp.ErrorResponseData("系统繁忙，请稍后再试~"), errors.New("Business Error")
+	}
 	// 创建一个新的用户对象，该对象的属性基于请求中的数据
 	newUser := model.User{
-		Name:     userReq.Username,
-		Password: userReq.Password,
-		IPInfo:   "{}",
+		Name:         userReq.Username,
+		NamePinyin:   utils.NamePinyin(userReq.Username),
+		Password:     hashedPassword,
+		IPInfo:       "{}",
+		Discoverable: domainEnum.Discoverable,
 	}
 	// 尝试在数据库中创建新的用户对象，如果出现错误，返回一个错误响应
 	if err := userQ.Omit(user.OpenID).Create(&newUser); err != nil {
@@ -64,76 +80,86 @@ func RegisterService(userReq req.UserRegisterReq) (pkgResp.ResponseData, error)
 }
 
 // LoginService 用户登录
-func LoginService(loginReq req.UserLoginReq) (pkgResp.ResponseData, error) {
+func LoginService(loginReq req.UserLoginReq, ip string) (pkgResp.ResponseData, error) {
 	ctx := context.Background()
 	user := query.User
 	userQ := user.WithContext(ctx)
-	// 查数据库
-	// 检查密码是否正确
-	fun := func() (interface{}, error) {
-		return userQ.Where(user.Name.Eq(loginReq.UserName), user.Password.Eq(loginReq.Password)).First()
+	// 连续失败次数过多，暂时禁止登录
+	if locked := checkLoginLocked(loginReq.UserName, ip); locked > 0 {
+		return loginLockedResponse(locked), errors.New("Business Error")
 	}
-	userR := model.User{}
-	key := fmt.Sprintf(domainEnum.UserCacheByName, loginReq.UserName)
-	err := utils.GetData(key, &userR, fun)
-	if err != nil {
+	// 查数据库，密码不写入缓存，这里不走缓存
+	userR, err := userQ.Where(user.Name.Eq(loginReq.UserName)).First()
+	if err != nil && !errors.Is(err, gorm.ErrRecordNotFound) {
 		global.Logger.Errorf("查询数据失败: %v", err)
+		return pkgResp.ErrorResponseData("系统繁忙，请稍后再试~"), errors.New("Business Error")
+	}
+	// 检查密码是否正确，用户不存在同样计入失败次数
+	ok, needRehash := false, false
+	if userR != nil {
+		ok, needRehash = utils.CheckPassword(userR.Password, loginReq.Password)
+	}
+	if !ok {
+		if locked := recordLoginFailure(loginReq.UserName, ip); locked > 0 {
+			return loginLockedResponse(locked), errors.New("Business Error")
+		}
 		return pkgResp.ErrorResponseData("用户名或密码错误"), errors.New("Business Error")
 	}
-	//生成jwt
-	token, err := utils.GenerateToken(userR.ID)
+	clearLoginFailure(loginReq.UserName, ip)
+	// 历史明文密码在登录成功后迁移为哈希
+	if needRehash {
+		if hashedPassword, err := utils.HashPassword(loginReq.Password); err != nil {
+			global.Logger.Errorf("密码哈希失败: %v", err)
+		} else if _, err := userQ.Where(user.ID.Eq(userR.ID)).Update(user.Password, hashedPassword); err != nil {
+			global.Logger.Errorf("更新密码失败: %v", err)
+		} else {
+			// 旧缓存中可能带有明文密码
+			redisCache.RemoveUserCache(*userR)
+		}
+	}
+	// 创建会话并签发令牌
+	sid, tokenResp, err := createSession(userR.ID)
 	if err != nil {
-		global.Logger.Errorf("生成jwt失败 %v", err)
 		return pkgResp.ErrorResponseData("系统繁忙，请稍后再试~"), errors.New("Business Error")
 	}
-	// 发送用户登录事件
-	userByte, err := json.Marshal(userR)
-	if err != nil {
-		global.Logger.Errorf("json序列化失败 %v", err)
+	// 登记登录设备
+	if err := registerDevice(userR, sid, loginReq.Platform, loginReq.DeviceName, ip); err != nil {
+		_ = revokeSession(userR.ID, sid)
+		return pkgResp.ErrorResponseData("系统繁忙，请稍后再试~"), errors.New("Business Error")
 	}
-	msg := &primitive.Message{
-		Topic: domainEnum.UserLoginTopic,
-		Body:  userByte,
+	// 发送用户登录事件，密码字段不参与序列化
+	if err := jsonUtils.SendMsgSync(domainEnum.UserLoginTopic, userR); err != nil {
+		global.Logger.Errorf("发送用户登录事件失败 %v", err)
 	}
-	_, _ = global.RocketProducer.SendSync(ctx, msg)
 	userResp := resp.UserLoginResp{
-		Token:  token,
-		Uid:    userR.ID,
-		Name:   userR.Name,
-		Avatar: userR.Avatar,
+		Token:        tokenResp.Token,
+		RefreshToken: tokenResp.RefreshToken,
+		ExpiresIn:    tokenResp.ExpiresIn,
+		Uid:          userR.ID,
+		Name:         userR.Name,
+		Avatar:       userR.Avatar,
 	}
 	return pkgResp.SuccessResponseData(userResp), nil
 }
 
-func GetUserInfoByNameService(uid int64, name string) (pkgResp.ResponseData, error) {
-	ctx := context.Background()
+// getUserByID 根据用户ID查询用户，优先走缓存
+func getUserByID(uid int64) (*model.User, error) {
 	user := global.Query.User
-	userQ := user.WithContext(ctx)
-	userRList, err := userQ.Where(user.Name.Like(name + "%")).Limit(5).Find()
-	if err != nil {
-		global.Logger.Errorf("查询用户数据失败: %v", err)
-		return pkgResp.ErrorResponseData("系统繁忙，请稍后再试~"), errors.New("Business Error")
-	}
-	uidList := make([]int64, 0)
-	for _, userR := range userRList {
-		uidList = append(uidList, userR.ID)
-	}
-	//	搜索好友关系
-	userApply := global.Query.UserApply
-	userApplyQ := userApply.WithContext(ctx)
-	applyList, err := userApplyQ.Where(userApply.UID.Eq(uid), userApply.TargetID.In(uidList...)).Find()
-	if err != nil {
-		global.Logger.Errorf("查询好友关系失败: %v", err)
-		return pkgResp.ErrorResponseData("系统繁忙，请稍后再试~"), errors.New("Business Error")
+	userQ := user.WithContext(context.Background())
+	fun := func() (interface{}, error) {
+		return userQ.Where(user.ID.Eq(uid)).First()
 	}
-	//	查询好友关系
-	userFriend := global.Query.UserFriend
-	userFriendQ := userFriend.WithContext(ctx)
-	friendList, err := userFriendQ.Where(userFriend.UID.Eq(uid), userFriend.FriendUID.In(uidList...)).Find()
-	if err != nil {
-		global.Logger.Errorf("查询好友关系失败: %v", err)
-		return pkgResp.ErrorResponseData("系统繁忙，请稍后再试~"), errors.New("Business Error")
+	userR := model.User{}
+	key := fmt.Sprintf(domainEnum.UserCacheByID, uid)
+	if err := utils.GetData(key, &userR, fun); err != nil {
+		return nil, err
 	}
-	userRespList := adapter.BuildUserInfoByNameResp(userRList, applyList, friendList)
-	return pkgResp.SuccessResponseData(userRespList), nil
+	return &userR, nil
+}
+
+// loginLockedResponse 登录锁定响应，返回剩余锁定秒数
+func loginLockedResponse(locked time.Duration) pkgResp.ResponseData {
+	return pkgResp.ErrorResponseDataWithCode(pkgEnum.ERROR_LOGIN_LOCKED, pkgResp.RetryAfterData{
+		RetryAfter: int64(math.Ceil(locked.Seconds())),
+	})
 }
@@ -62,8 +62,11 @@ create table message
     gap_count    int                                      null comment '与回复的消息间隔多少条',
     type         int         default 1                    null comment '消息类型 1正常文本 2.撤回消息',
     extra        json                                     null comment '扩展信息',
+    client_msg_id varchar(64) default null                     comment '客户端消息id，同一发送者唯一',
     create_time  datetime(3) default CURRENT_TIMESTAMP(3) not null comment '创建时间',
-    update_time  datetime(3) default CURRENT_TIMESTAMP(3) not null on update CURRENT_TIMESTAMP(3) comment '修改时间'
+    update_time  datetime(3) default CURRENT_TIMESTAMP(3) not null on update CURRENT_TIMESTAMP(3) comment '修改时间',
+    constraint uniq_from_uid_client_msg_id
+        unique (from_uid, client_msg_id)
 )
     comment '消息表' collate = utf8mb4_unicode_ci
                      row_format = DYNAMIC;
@@ -208,3 +211,255 @@ create index idx_uid_target_id
 create index idx_update_time
     on user_apply (update_time);
 
+-- 历史图片消息以 0 写入删除状态，统一为正常状态
+update message
+set delete_status = 1
+where type = 3
+  and delete_status = 0;
+
+
+-- auto-generated definition
+create table event_outbox
+(
+    id              bigint unsigned auto_increment comment 'id'
+        primary key,
+    topic           varchar(64)                              not null comment '消息主题',
+    body            text                                     not null comment '消息内容',
+    status          int         default 1                    not null comment '发送状态 1待发送 2已发送',
+    retry_count     int         default 0                    not null comment '重试次数',
+    next_retry_time datetime(3) default CURRENT_TIMESTAMP(3) not null comment '下次发送时间',
+    create_time     datetime(3) default CURRENT_TIMESTAMP(3) not null comment '创建时间',
+    update_time     datetime(3) default CURRENT_TIMESTAMP(3) not null on update CURRENT_TIMESTAMP(3) comment '修改时间'
+)
+    comment '事件发件箱' collate = utf8mb4_unicode_ci;
+
+create index idx_status_next_retry_time
+    on event_outbox (status, next_retry_time);
+
+-- auto-generated definition
+create table user_device
+(
+    id             bigint unsigned auto_increment comment 'id'
+        primary key,
+    uid            bigint                                   not null comment '用户id',
+    sid            varchar(64)                              not null comment '登录会话id',
+    platform       int                                      not null comment '平台 1网页 2桌面 3移动',
+    name           varchar(64) default ''                   not null comment '设备名称',
+    ip             varchar(64) default ''                   not null comment '登录ip',
+    status         int         default 1                    not null comment '状态 1在线 2已下线',
+    last_seen_time datetime(3) default CURRENT_TIMESTAMP(3) not null comment '最后活跃时间',
+    create_time    datetime(3) default CURRENT_TIMESTAMP(3) not null comment '创建时间',
+    update_time    datetime(3) default CURRENT_TIMESTAMP(3) not null on update CURRENT_TIMESTAMP(3) comment '修改时间',
+    constraint uniq_sid
+        unique (sid)
+)
+    comment '用户登录设备' collate = utf8mb4_unicode_ci;
+
+create index idx_uid_status
+    on user_device (uid, status);
+
+alter table user
+    add signature varchar(100) default '' not null comment '个性签名' after sex;
+
+-- auto-generated definition
+create table user_block
+(
+    id            bigint unsigned auto_increment comment 'id'
+        primary key,
+    uid           bigint                                   not null comment 'uid',
+    target_uid    bigint                                   not null comment '被拉黑的uid',
+    delete_status int(1)      default 1                    not null comment '状态 1拉黑 2已移出',
+    create_time   datetime(3) default CURRENT_TIMESTAMP(3) not null comment '创建时间',
+    update_time   datetime(3) default CURRENT_TIMESTAMP(3) not null on update CURRENT_TIMESTAMP(3) comment '修改时间',
+    constraint uk_uid_target_uid
+        unique (uid, target_uid)
+)
+    comment '用户黑名单表' collate = utf8mb4_unicode_ci;
+
+create index idx_target_uid
+    on user_block (target_uid);
+
+alter table user_friend
+    add remark varchar(32) default '' not null comment '好友备注' after friend_uid,
+    add tags varchar(255) default '' not null comment '好友标签，逗号分隔' after remark;
+
+alter table user_apply
+    modify status int not null comment '申请状态 1待审批 2同意 3拒绝 4过期';
+
+alter table user
+    add name_pinyin varchar(255) default '' not null comment '昵称拼音，全拼和首字母，用于搜索' after name,
+    add discoverable int default 1 not null comment '是否允许通过昵称搜索 1允许 2不允许' after signature;
+
+alter table message
+    add edit_time datetime(3) default null null comment '最后编辑时间，未编辑为空' after client_msg_id;
+
+create table message_edit_history
+(
+    id          bigint unsigned auto_increment comment 'id'
+        primary key,
+    msg_id      bigint                                   not null comment '消息id',
+    content     varchar(1024)                            null comment '编辑前的消息内容',
+    create_time datetime(3) default CURRENT_TIMESTAMP(3) not null comment '编辑时间'
+)
+    comment '消息编辑历史表' collate = utf8mb4_unicode_ci
+                         row_format = DYNAMIC;
+
+create index idx_msg_id
+    on message_edit_history (msg_id);
+
+create table message_reaction
+(
+    id          bigint unsigned auto_increment comment 'id'
+        primary key,
+    msg_id      bigint                                   not null comment '消息id',
+    uid         bigint                                   not null comment '回应的用户uid',
+    emoji       varchar(32) collate utf8mb4_bin          not null comment '表情',
+    create_time datetime(3) default CURRENT_TIMESTAMP(3) not null comment '创建时间',
+    constraint uniq_msg_id_uid_emoji
+        unique (msg_id, uid, emoji)
+)
+    comment '消息表情回应表' collate = utf8mb4_unicode_ci
+                         row_format = DYNAMIC;
+
+alter table message
+    add forwarded int default 1 not null comment '是否转发的消息 1否 2是' after client_msg_id;
+
+create table message_pin
+(
+    id          bigint unsigned auto_increment comment 'id'
+        primary key,
+    room_id     bigint                                   not null comment '房间id',
+    msg_id      bigint                                   not null comment '消息id',
+    uid         bigint                                   not null comment '置顶操作人uid',
+    create_time datetime(3) default CURRENT_TIMESTAMP(3) not null comment '置顶时间',
+    constraint uniq_room_id_msg_id
+        unique (room_id, msg_id)
+)
+    comment '房间置顶消息表' collate = utf8mb4_unicode_ci
+                         row_format = DYNAMIC;
+
+create table user_favorite
+(
+    id            bigint unsigned auto_increment comment 'id'
+        primary key,
+    uid           bigint                                   not null comment '收藏的用户uid',
+    msg_id        bigint                                   not null comment '原消息id',
+    room_id       bigint                                   not null comment '原消息所在房间id',
+    from_uid      bigint                                   not null comment '消息发送者uid',
+    from_name     varchar(32)  default ''                  null comment '收藏时发送者的昵称',
+    from_avatar   varchar(255) default ''                  null comment '收藏时发送者的头像',
+    msg_type      int                                      not null comment '消息类型',
+    content       varchar(1024)                            null comment '消息内容',
+    extra         json                                     null comment '消息扩展信息',
+    send_time     datetime(3)                              not null comment '消息发送时间',
+    tags          varchar(255) default ''                  not null comment '标签，逗号分隔',
+    note          varchar(255) default ''                  not null comment '备注',
+    create_time   datetime(3)  default CURRENT_TIMESTAMP(3) not null comment '收藏时间',
+    update_time   datetime(3)  default CURRENT_TIMESTAMP(3) not null on update CURRENT_TIMESTAMP(3) comment '修改时间',
+    constraint uniq_uid_msg_id
+        unique (uid, msg_id)
+)
+    comment '用户收藏表' collate = utf8mb4_unicode_ci
+                    row_format = DYNAMIC;
+
+create table scheduled_message
+(
+    id            bigint unsigned auto_increment comment 'id'
+        primary key,
+    uid           bigint                                   not null comment '发送者uid',
+    room_id       bigint                                   not null comment '房间id',
+    type          int                                      not null comment '消息类型',
+    content       varchar(1024)                            null comment '消息内容',
+    reply_msg_id  bigint                                   null comment '回复的消息id',
+    client_msg_id varchar(64) default null                 comment '客户端消息id，同一发送者唯一',
+    send_at       datetime(3)                              not null comment '计划发送时间',
+    status        int         default 1                    not null comment '状态 1待发送 2已发送 3已取消 4发送失败',
+    msg_id        bigint                                   null comment '发送后的消息id',
+    create_time   datetime(3) default CURRENT_TIMESTAMP(3) not null comment '创建时间',
+    update_time   datetime(3) default CURRENT_TIMESTAMP(3) not null on update CURRENT_TIMESTAMP(3) comment '修改时间',
+    constraint uniq_uid_client_msg_id
+        unique (uid, client_msg_id)
+)
+    comment '定时消息表' collate = utf8mb4_unicode_ci
+                    row_format = DYNAMIC;
+
+create index idx_status_send_at
+    on scheduled_message (status, send_at);
+
+create index idx_uid_status
+    on scheduled_message (uid, status);
+
+alter table room
+    add msg_ttl int default 0 not null comment '消息自毁时长(秒)，0为关闭' after last_msg_id;
+
+alter table message
+    add expire_at datetime(3) default null null comment '自毁时间，为空不自毁' after forwarded;
+
+create index idx_expire_at
+    on message (expire_at);
+
+create table poll_vote
+(
+    id          bigint unsigned auto_increment comment 'id'
+        primary key,
+    msg_id      bigint                                   not null comment '投票消息id',
+    uid         bigint                                   not null comment '投票的用户uid',
+    option_idx  int                                      not null comment '选项下标',
+    create_time datetime(3) default CURRENT_TIMESTAMP(3) not null comment '投票时间',
+    constraint uniq_msg_id_uid_option_idx
+        unique (msg_id, uid, option_idx)
+)
+    comment '投票记录表' collate = utf8mb4_unicode_ci
+                         row_format = DYNAMIC;
+
+create table object_ref
+(
+    id          bigint unsigned auto_increment comment 'id'
+        primary key,
+    object_name varchar(256)                             not null comment '文件在存储桶中的名称',
+    ref_type    int                                      not null comment '引用方类型 1消息 2收藏',
+    ref_id      bigint                                   not null comment '引用方id',
+    create_time datetime(3) default CURRENT_TIMESTAMP(3) not null comment '创建时间',
+    constraint uniq_ref_type_ref_id_object_name
+        unique (ref_type, ref_id, object_name)
+)
+    comment '文件引用表' collate = utf8mb4_unicode_ci
+                         row_format = DYNAMIC;
+
+create index idx_object_name
+    on object_ref (object_name);
+
+-- 补全已有图片消息、聊天记录和收藏的文件引用
+insert ignore into object_ref (object_name, ref_type, ref_id)
+select json_unquote(json_extract(extra, '$.message_base_dto.name')), 1, id
+from message
+where type = 3
+  and delete_status = 1
+  and json_extract(extra, '$.message_base_dto.name') is not null;
+
+insert ignore into object_ref (object_name, ref_type, ref_id)
+select json_unquote(json_extract(item.extra, '$.message_base_dto.name')), 1, m.id
+from message m,
+     json_table(m.extra, '$.messages[*]' columns (type int path '$.type', extra json path '$.extra')) item
+where m.type = 4
+  and m.delete_status = 1
+  and item.type = 3
+  and json_extract(item.extra, '$.message_base_dto.name') is not null;
+
+insert ignore into object_ref (object_name, ref_type, ref_id)
+select json_unquote(json_extract(extra, '$.message_base_dto.name')), 2, id
+from user_favorite
+where msg_type = 3
+  and json_extract(extra, '$.message_base_dto.name') is not null;
+
+insert ignore into object_ref (object_name, ref_type, ref_id)
+select json_unquote(json_extract(item.extra, '$.message_base_dto.name')), 2, f.id
+from user_favorite f,
+     json_table(f.extra, '$.messages[*]' columns (type int path '$.type', extra json path '$.extra')) item
+where f.msg_type = 4
+  and item.type = 3
+  and json_extract(item.extra, '$.message_base_dto.name') is not null;
+
+-- 清理已发送的发件箱事件
+create index idx_status_update_time
+    on event_outbox (status, update_time);
@@ -2,16 +2,15 @@ package jsonUtils
 
 import (
 	"DiTing-Go/global"
+	"DiTing-Go/utils/mq"
 	"context"
-	"github.com/apache/rocketmq-client-go/v2/primitive"
 	"github.com/goccy/go-json"
 	"github.com/pkg/errors"
 )
 
 // UnmarshalMsg 解析消息队列msg
-func UnmarshalMsg(item any, msg *primitive.MessageExt) error {
-	byteStr := msg.Message.Body
-	err := json.Unmarshal(byteStr, item)
+func UnmarshalMsg(item any, body []byte) error {
+	err := json.Unmarshal(body, item)
 	if err != nil {
 		global.Logger.Errorf("jsonUtils unmarshal error: %s", err.Error())
 		return errors.New("Business Error")
@@ -36,10 +35,5 @@ func SendMsgSync(topic string, item any) error {
 	if err != nil {
 		return err
 	}
-	msg := &primitive.Message{
-		Topic: topic,
-		Body:  byteStr,
-	}
-	_, err = global.RocketProducer.SendSync(ctx, msg)
-	return err
+	return mq.Default.Publish(ctx, topic, byteStr)
 }
@@ -0,0 +1,56 @@
+package mq
+
+import (
+	"DiTing-Go/global"
+	"context"
+	"github.com/asaskevich/EventBus"
+	"time"
+)
+
+const (
+	// 进程内消息处理失败的最大重试次数
+	memoryMaxRetry = 3
+	// 进程内消息重试间隔
+	memoryRetryInterval = time.Second
+)
+
+// memoryBus 基于 EventBus 的进程内消息总线，消息不持久化，进程退出后未消费的消息会丢失
+type memoryBus struct {
+	bus EventBus.Bus
+}
+
+// NewMemoryBus 创建进程内消息总线
+func NewMemoryBus(bus EventBus.Bus) Bus {
+	return &memoryBus{bus: bus}
+}
+
+func (b *memoryBus) Publish(ctx context.Context, topic string, body []byte) error {
+	b.bus.Publish(topic, ctx, body)
+	return nil
+}
+
+// Subscribe 进程内每个订阅都会收到全部消息，消费组仅用于日志
+func (b *memoryBus) Subscribe(topic string, group string, handler Handler) error {
+	return b.bus.SubscribeAsync(topic, func(ctx context.Context, body []byte) {
+		for i := 0; ; i++ {
+			err := handler(context.WithoutCancel(ctx), body)
+			if err == nil {
+				return
+			}
+			if i >= memoryMaxRetry {
+				global.Logger.Errorf("消费消息失败 %s %s %s", topic, group, err)
+				return
+			}
+			time.Sleep(memoryRetryInterval << i)
+		}
+	}, false)
+}
+
+// SubscribeBroadcast 进程内只有一个实例，与普通订阅相同
+func (b *memoryBus) SubscribeBroadcast(topic string, handler Handler) error {
+	return b.Subscribe(topic, topic+"-broadcast", handler)
+}
+
+func (b *memoryBus) Start() error {
+	return nil
+}
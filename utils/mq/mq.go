@@ -0,0 +1,58 @@
+package mq
+
+import (
+	"DiTing-Go/global"
+	_ "DiTing-Go/pkg/setting"
+	"context"
+	"github.com/spf13/viper"
+)
+
+const (
+	// TypeRocketMQ 使用 RocketMQ 投递消息
+	TypeRocketMQ = "rocketmq"
+	// TypeMemory 进程内投递消息，适用于单机部署和本地开发
+	TypeMemory = "memory"
+)
+
+// Handler 消息处理函数，返回错误时消息会被重新投递
+type Handler func(ctx context.Context, body []byte) error
+
+// Publisher 消息发布者
+type Publisher interface {
+	// Publish 发布消息到指定主题
+	Publish(ctx context.Context, topic string, body []byte) error
+}
+
+// Subscriber 消息订阅者
+type Subscriber interface {
+	// Subscribe 订阅主题，同一消费组内的订阅者共同消费一份消息
+	Subscribe(topic string, group string, handler Handler) error
+	// SubscribeBroadcast 广播订阅主题，每个实例都会收到全部消息，用于清理本地状态
+	SubscribeBroadcast(topic string, handler Handler) error
+	// Start 所有订阅注册完成后开始消费
+	Start() error
+}
+
+// Bus 消息总线
+type Bus interface {
+	Publisher
+	Subscriber
+}
+
+// Default 默认消息总线，由配置 mq.type 决定具体实现
+var Default Bus
+
+func init() {
+	var err error
+	switch mqType := viper.GetString("mq.type"); mqType {
+	case TypeMemory:
+		Default = NewMemoryBus(global.Bus)
+	case TypeRocketMQ, "":
+		Default, err = NewRocketMQBus(viper.GetString("rocketmq.host"), viper.GetString("rocketmq.group"))
+	default:
+		global.Logger.Panicf("unknown mq type: %s", mqType)
+	}
+	if err != nil {
+		global.Logger.Panicf("init mq error: %s", err.Error())
+	}
+}
@@ -0,0 +1,96 @@
+package mq
+
+import (
+	"DiTing-Go/global"
+	"context"
+	"github.com/apache/rocketmq-client-go/v2"
+	"github.com/apache/rocketmq-client-go/v2/consumer"
+	"github.com/apache/rocketmq-client-go/v2/primitive"
+	"github.com/apache/rocketmq-client-go/v2/producer"
+)
+
+// rocketMQBus 基于 RocketMQ 的消息总线，每个消费组对应一个推送消费者
+type rocketMQBus struct {
+	host      string
+	producer  rocketmq.Producer
+	consumers map[string]rocketmq.PushConsumer
+}
+
+// NewRocketMQBus 创建并启动 RocketMQ 生产者
+func NewRocketMQBus(host string, group string) (Bus, error) {
+	rocketProducer, err := rocketmq.NewProducer(
+		// 设置  nameSrvAddr
+		// nameSrvAddr 是 Topic 路由注册中心
+		producer.WithNameServer([]string{host}),
+		// 指定发送失败时的重试时间
+		producer.WithRetry(3),
+		// 设置 Group
+		producer.WithGroupName(group),
+	)
+	if err != nil {
+		return nil, err
+	}
+	// 开始连接
+	if err := rocketProducer.Start(); err != nil {
+		return nil, err
+	}
+	return &rocketMQBus{
+		host:      host,
+		producer:  rocketProducer,
+		consumers: make(map[string]rocketmq.PushConsumer),
+	}, nil
+}
+
+func (b *rocketMQBus) Publish(ctx context.Context, topic string, body []byte) error {
+	msg := &primitive.Message{
+		Topic: topic,
+		Body:  body,
+	}
+	_, err := b.producer.SendSync(ctx, msg)
+	return err
+}
+
+func (b *rocketMQBus) Subscribe(topic string, group string, handler Handler) error {
+	return b.subscribe(topic, group, consumer.Clustering, handler)
+}
+
+func (b *rocketMQBus) SubscribeBroadcast(topic string, handler Handler) error {
+	return b.subscribe(topic, topic+"-broadcast", consumer.BroadCasting, handler)
+}
+
+func (b *rocketMQBus) subscribe(topic string, group string, model consumer.MessageModel, handler Handler) error {
+	rocketConsumer, ok := b.consumers[group]
+	if !ok {
+		var err error
+		rocketConsumer, err = rocketmq.NewPushConsumer(
+			//消费组
+			consumer.WithGroupName(group),
+			// namesrv地址
+			consumer.WithNameServer([]string{b.host}),
+			// 集群消费或广播消费
+			consumer.WithConsumerModel(model),
+		)
+		if err != nil {
+			return err
+		}
+		b.consumers[group] = rocketConsumer
+	}
+	return rocketConsumer.Subscribe(topic, consumer.MessageSelector{}, func(ctx context.Context, ext ...*primitive.MessageExt) (consumer.ConsumeResult, error) {
+		for i := range ext {
+			if err := handler(ctx, ext[i].Message.Body); err != nil {
+				global.Logger.Errorf("消费消息失败 %s %s", topic, err)
+				return consumer.ConsumeRetryLater, nil
+			}
+		}
+		return consumer.ConsumeSuccess, nil
+	})
+}
+
+func (b *rocketMQBus) Start() error {
+	for _, rocketConsumer := range b.consumers {
+		if err := rocketConsumer.Start(); err != nil {
+			return err
+		}
+	}
+	return nil
+}
@@ -0,0 +1,119 @@
+package outbox
+
+import (
+	"DiTing-Go/dal/model"
+	"DiTing-Go/dal/query"
+	"DiTing-Go/domain/enum"
+	"DiTing-Go/global"
+	pkgEnum "DiTing-Go/pkg/domain/enum"
+	"DiTing-Go/utils/jsonUtils"
+	"DiTing-Go/utils/mq"
+	"context"
+	"github.com/go-redsync/redsync/v4"
+	"time"
+)
+
+const (
+	// 每轮最多投递的事件数
+	relayBatchSize = 100
+	// 投递间隔
+	relayInterval = time.Second
+	// 最大重试间隔
+	maxRetryInterval = 5 * time.Minute
+	// 清理已发送事件的间隔
+	purgeInterval = time.Hour
+	// 已发送事件的保留时长
+	purgeRetention = 7 * 24 * time.Hour
+	// 每次最多删除的事件数，避免长时间锁表
+	purgeBatchSize = 1000
+)
+
+// lastPurgeTime 上次清理已发送事件的时间
+var lastPurgeTime time.Time
+
+// Save 在业务事务中写入待发送事件，事务提交后由 Relay 投递到消息总线
+func Save(tx *query.QueryTx, topic string, item any) error {
+	byteStr, err := jsonUtils.Marshal(item)
+	if err != nil {
+		return err
+	}
+	return tx.EventOutbox.WithContext(context.Background()).Create(&model.EventOutbox{
+		Topic:         topic,
+		Body:          string(byteStr),
+		Status:        pkgEnum.NO,
+		NextRetryTime: time.Now(),
+	})
+}
+
+// Relay 周期性地投递发件箱中的事件，发送失败按指数退避重试
+func Relay() {
+	ticker := time.NewTicker(relayInterval)
+	defer ticker.Stop()
+	for range ticker.C {
+		relay()
+	}
+}
+
+func relay() {
+	// 多实例部署时只允许一个实例投递，没抢到锁直接跳过本轮
+	mutex := global.RedSync.NewMutex(enum.OutboxLock, redsync.WithExpiry(30*time.Second))
+	if err := mutex.TryLock(); err != nil {
+		return
+	}
+	defer mutex.Unlock()
+
+	ctx := context.Background()
+	eventOutbox := global.Query.EventOutbox
+	eventOutboxQ := eventOutbox.WithContext(ctx)
+	events, err := eventOutboxQ.Where(eventOutbox.Status.Eq(pkgEnum.NO), eventOutbox.NextRetryTime.Lte(time.Now())).Order(eventOutbox.ID).Limit(relayBatchSize).Find()
+	if err != nil {
+		global.Logger.Errorf("查询发件箱失败 %s", err)
+		return
+	}
+	for _, event := range events {
+		if err := mq.Default.Publish(ctx, event.Topic, []byte(event.Body)); err != nil {
+			global.Logger.Errorf("投递事件失败 %d %s", event.ID, err)
+			if _, err := eventOutboxQ.Where(eventOutbox.ID.Eq(event.ID)).UpdateSimple(eventOutbox.RetryCount.Add(1), eventOutbox.NextRetryTime.Value(time.Now().Add(retryInterval(event.RetryCount)))); err != nil {
+				global.Logger.Errorf("更新发件箱失败 %s", err)
+			}
+			continue
+		}
+		if _, err := eventOutboxQ.Where(eventOutbox.ID.Eq(event.ID)).Update(eventOutbox.Status, pkgEnum.YES); err != nil {
+			global.Logger.Errorf("更新发件箱失败 %s", err)
+		}
+	}
+
+	if time.Since(lastPurgeTime) >= purgeInterval {
+		purge()
+		lastPurgeTime = time.Now()
+	}
+}
+
+// purge 分批删除超过保留时长的已发送事件，调用方需持有发件箱锁
+func purge() {
+	eventOutbox := global.Query.EventOutbox
+	eventOutboxQ := eventOutbox.WithContext(context.Background())
+	deadline := time.Now().Add(-purgeRetention)
+	for {
+		result, err := eventOutboxQ.Where(eventOutbox.Status.Eq(pkgEnum.YES), eventOutbox.UpdateTime.Lt(deadline)).Limit(purgeBatchSize).Delete()
+		if err != nil {
+			global.Logger.Errorf("清理发件箱失败 %s", err)
+			return
+		}
+		if result.RowsAffected < purgeBatchSize {
+			return
+		}
+	}
+}
+
+// retryInterval 第 n 次重试的等待时间
+func retryInterval(retryCount int32) time.Duration {
+	if retryCount > 16 {
+		return maxRetryInterval
+	}
+	interval := time.Second << retryCount
+	if interval > maxRetryInterval {
+		return maxRetryInterval
+	}
+	return interval
+}
@@ -0,0 +1,28 @@
+//go:build integration
+
+package outbox
+
+import (
+	"testing"
+	"time"
+)
+
+func TestRetryInterval(t *testing.T) {
+	tests := []struct {
+		retryCount int32
+		want       time.Duration
+	}{
+		{retryCount: 0, want: time.Second},
+		{retryCount: 1, want: 2 * time.Second},
+		{retryCount: 8, want: 256 * time.Second},
+		{retryCount: 9, want: maxRetryInterval},
+		{retryCount: 16, want: maxRetryInterval},
+		{retryCount: 17, want: maxRetryInterval},
+		{retryCount: 64, want: maxRetryInterval},
+	}
+	for _, tt := range tests {
+		if got := retryInterval(tt.retryCount); got != tt.want {
+			t.Errorf("retryInterval(%d) = %v, want %v", tt.retryCount, got, tt.want)
+		}
+	}
+}
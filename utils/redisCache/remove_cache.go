@@ -36,6 +36,18 @@ func RemoveUserApply(uid, friendUid int64) {
 	utils.RemoveData(fmt.Sprintf(enum.UserApplyCacheByUidAndFriendUid, friendUid, uid))
 }
 
+// RemoveUserBlock 移除用户黑名单缓存
+func RemoveUserBlock(uid int64) {
+	utils.RemoveData(fmt.Sprintf(enum.UserBlockCacheByUid, uid))
+}
+
+// RemoveUserRecommend 移除好友推荐缓存
+func RemoveUserRecommend(uids ...int64) {
+	for _, uid := range uids {
+		utils.RemoveData(fmt.Sprintf(enum.UserRecommendCache, uid))
+	}
+}
+
 // RemoveContact 移除会话缓存
 func RemoveContact(contact model.Contact) {
 	utils.RemoveData(fmt.Sprintf(enum.ContactCacheById, contact.ID))
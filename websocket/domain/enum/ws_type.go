@@ -2,4 +2,20 @@ package enum
 
 const (
 	NewMessage = 4
+	// 用户资料变更，前端收到后重新拉取该用户信息
+	UserProfileChanged = 5
+	// 收到好友申请
+	FriendApply = 6
+	// 好友申请已通过
+	FriendApplyAccepted = 7
+	// 被删除好友
+	FriendDeleted = 8
+	// 消息被编辑
+	MessageEdited = 9
+	// 消息表情回应变化，不影响会话排序
+	MessageReaction = 10
+	// 房间置顶消息变化
+	MessagePinned = 11
+	// 投票结果变化
+	PollUpdated = 12
 )
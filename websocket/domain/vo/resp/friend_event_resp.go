@@ -0,0 +1,9 @@
+package resp
+
+type FriendEventResp struct {
+	Type   int    `json:"type"`             // 消息类型
+	Uid    int64  `json:"uid"`              // 对方用户ID
+	Name   string `json:"name,omitempty"`   // 对方昵称
+	Avatar string `json:"avatar,omitempty"` // 对方头像
+	Msg    string `json:"msg,omitempty"`    // 申请信息
+}
@@ -0,0 +1,9 @@
+package resp
+
+type MessageEditedResp struct {
+	Type     int    `json:"type"`     // 消息类型
+	MsgId    int64  `json:"msgId"`    // 消息ID
+	RoomId   int64  `json:"roomId"`   // 房间ID
+	Content  string `json:"content"`  // 编辑后的消息内容
+	EditTime int64  `json:"editTime"` // 编辑时间 毫秒时间戳
+}
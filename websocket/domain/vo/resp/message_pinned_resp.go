@@ -0,0 +1,9 @@
+package resp
+
+type MessagePinnedResp struct {
+	Type   int   `json:"type"`   // 消息类型
+	RoomId int64 `json:"roomId"` // 房间ID
+	MsgId  int64 `json:"msgId"`  // 消息ID
+	Uid    int64 `json:"uid"`    // 操作的用户ID
+	Action int32 `json:"action"` // 1置顶 2取消置顶
+}
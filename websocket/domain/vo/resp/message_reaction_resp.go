@@ -0,0 +1,11 @@
+package resp
+
+type MessageReactionResp struct {
+	Type   int    `json:"type"`   // 消息类型
+	MsgId  int64  `json:"msgId"`  // 消息ID
+	RoomId int64  `json:"roomId"` // 房间ID
+	Uid    int64  `json:"uid"`    // 操作的用户ID
+	Emoji  string `json:"emoji"`  // 表情
+	Action int32  `json:"action"` // 1添加 2取消
+	Count  int64  `json:"count"`  // 该表情当前的回应数
+}
@@ -1,5 +1,8 @@
 package resp
 
 type NewMessageResp struct {
-	Type int `json:"type"` // 消息类型
+	Type        int    `json:"type"`                  // 消息类型
+	MsgId       int64  `json:"msgId,omitempty"`       // 消息ID
+	RoomId      int64  `json:"roomId,omitempty"`      // 房间ID
+	ClientMsgId string `json:"clientMsgId,omitempty"` // 客户端消息ID，用于乐观更新对账
 }
@@ -0,0 +1,9 @@
+package resp
+
+type PollUpdatedResp struct {
+	Type       int     `json:"type"`       // 消息类型
+	MsgId      int64   `json:"msgId"`      // 投票消息ID
+	RoomId     int64   `json:"roomId"`     // 房间ID
+	VoterCount int64   `json:"voterCount"` // 参与投票的人数
+	Counts     []int64 `json:"counts"`     // 各选项的票数，按选项下标排列
+}
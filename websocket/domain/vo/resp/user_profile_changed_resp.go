@@ -0,0 +1,6 @@
+package resp
+
+type UserProfileChangedResp struct {
+	Type int   `json:"type"` // 消息类型
+	Uid  int64 `json:"uid"`  // 资料变更的用户ID
+}
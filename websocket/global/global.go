@@ -8,12 +8,14 @@ import (
 
 type Channels struct {
 	Uid         int64
-	ChannelList []*websocket.Conn
+	ChannelList []*User
 	Mu          *sync.RWMutex
 }
 type User struct {
 	Uid     int64
+	Sid     string // 建立连接时使用的登录会话
 	Channel *websocket.Conn
+	WriteMu *sync.Mutex // 连接不支持并发写，推送、心跳和断开通知都需持有该锁
 }
 type Msg struct {
 	Uid int64
@@ -8,6 +8,7 @@ import (
 	"github.com/gorilla/websocket"
 	"github.com/pkg/errors"
 	"log"
+	"math"
 	"net/http"
 	"strconv"
 	"strings"
@@ -15,6 +16,9 @@ import (
 	"time"
 )
 
+// 上行单帧最大字节数
+const maxFrameSize = 4096
+
 // TODO:连接断开处理
 // 定义一个升级器，将普通的http连接升级为websocket连接
 var upgrader = &websocket.Upgrader{
@@ -39,8 +43,8 @@ func Connect(w http.ResponseWriter, r *http.Request) {
 	params := r.URL.Query()
 	token := params.Get("token")
 
-	// 解析token并获取用户信息
-	tokenInfo, err := utils.ParseToken(token)
+	// 解析token并获取用户信息，会话已吊销的token不允许建立连接
+	tokenInfo, err := utils.CheckToken(token)
 	if err != nil {
 		global2.Logger.Errorf("无权限访问: %v", err)
 		return
@@ -63,26 +67,33 @@ func Connect(w http.ResponseWriter, r *http.Request) {
 	// 初始化用户频道信息
 	userChannel := global.Channels{
 		Uid:         *uid,
-		ChannelList: make([]*websocket.Conn, 0),
+		ChannelList: make([]*global.User, 0),
 		Mu:          new(sync.RWMutex),
 	}
 	user := global.User{
 		Uid:     *uid,
+		Sid:     tokenInfo.Sid,
 		Channel: conn,
+		WriteMu: new(sync.Mutex),
 	}
 
-	// 将用户频道信息存储到全局用户频道映射表中
-	global.UserChannelMap.Set(stringUid, &userChannel)
+	// 将用户频道信息存储到全局用户频道映射表中，已有其他连接时沿用原来的频道信息
+	global.UserChannelMap.SetIfAbsent(stringUid, &userChannel)
 	userChannelPtr, _ := global.UserChannelMap.Get(stringUid)
 
 	// 将连接加入到用户的频道列表中
 	userChannelPtr.Mu.Lock()
-	userChannelPtr.ChannelList = append(userChannelPtr.ChannelList, conn)
+	userChannelPtr.ChannelList = append(userChannelPtr.ChannelList, &user)
 	userChannelPtr.Mu.Unlock()
 
 	// 开始定时发送心跳消息以保持连接
 	go heatBeat(&user)
 
+	// 限制单帧大小和上行帧频率
+	conn.SetReadLimit(maxFrameSize)
+	rateLimitRule := utils.NewRateLimitRule("websocket", 10, 30)
+	subject := "uid:" + stringUid
+
 	// 监听WebSocket连接上的消息
 	for {
 		_, _, err := conn.ReadMessage()
@@ -91,6 +102,13 @@ func Connect(w http.ResponseWriter, r *http.Request) {
 			disConnect(&user)
 			break
 		}
+		if allowed, wait := rateLimitRule.Allow(subject); !allowed {
+			// 超出频率限制直接断开，客户端按 retry after 等待后重连
+			reason := fmt.Sprintf("too many requests, retry after %ds", int64(math.Ceil(wait.Seconds())))
+			_ = writeClose(&user, reason)
+			disConnect(&user)
+			break
+		}
 	}
 }
 
@@ -102,15 +120,36 @@ func Send(uid int64, value []byte) error {
 	if channels == nil {
 		return nil
 	}
-	for _, conn := range channels.ChannelList {
-		// 发送空消息，代表有新消息
-		err := conn.WriteMessage(websocket.TextMessage, value)
-		if err != nil {
+	// 连接列表会被建立和断开连接并发修改，读锁下复制一份再发送
+	channels.Mu.RLock()
+	users := make([]*global.User, len(channels.ChannelList))
+	copy(users, channels.ChannelList)
+	channels.Mu.RUnlock()
+
+	var sendErr error
+	for _, user := range users {
+		// 发送空消息，代表有新消息，单个连接失败不影响其他设备
+		if err := writeMessage(user, websocket.TextMessage, value); err != nil {
 			global2.Logger.Errorf("发送消息失败: %v", err)
-			return errors.New("Business Error")
+			sendErr = errors.New("Business Error")
 		}
 	}
-	return nil
+	return sendErr
+}
+
+// writeMessage 串行写入数据帧
+func writeMessage(user *global.User, messageType int, data []byte) error {
+	user.WriteMu.Lock()
+	defer user.WriteMu.Unlock()
+	return user.Channel.WriteMessage(messageType, data)
+}
+
+// writeClose 串行写入关闭帧，通知客户端断开原因
+func writeClose(user *global.User, reason string) error {
+	user.WriteMu.Lock()
+	defer user.WriteMu.Unlock()
+	closeMsg := websocket.FormatCloseMessage(websocket.ClosePolicyViolation, reason)
+	return user.Channel.WriteControl(websocket.CloseMessage, closeMsg, time.Now().Add(time.Second))
 }
 
 // 移除连接
@@ -129,22 +168,42 @@ func disConnect(user *global.User) {
 
 	// 遍历用户的频道列表，查找并移除指定的 WebSocket 连接
 	for i, item := range userChannel.ChannelList {
-		if item == conn {
+		if item.Channel == conn {
 			// 移除匹配的连接
 			userChannel.ChannelList = append(userChannel.ChannelList[:i], userChannel.ChannelList[i+1:]...)
 			break // 找到连接并移除后退出循环
 		}
 	}
 
+	// 解锁用户频道，连接可能已被关闭，关闭失败时不能继续持有锁
+	userChannel.Mu.Unlock()
+
 	// 关闭 WebSocket 连接
-	err := conn.Close()
-	if err != nil {
-		// 如果关闭连接失败，记录错误并返回
+	_ = conn.Close()
+}
+
+// DisconnectSession 断开指定会话的所有连接，会话被吊销时调用
+func DisconnectSession(uid int64, sid string) {
+	stringUid := strconv.FormatInt(uid, 10)
+	userChannel, _ := global.UserChannelMap.Get(stringUid)
+	// 用户不在本实例上
+	if userChannel == nil {
 		return
 	}
+	userChannel.Mu.RLock()
+	users := make([]*global.User, 0)
+	for _, item := range userChannel.ChannelList {
+		if item.Sid == sid {
+			users = append(users, item)
+		}
+	}
+	userChannel.Mu.RUnlock()
 
-	// 解锁用户频道
-	userChannel.Mu.Unlock()
+	for _, user := range users {
+		// 通知客户端会话已失效，读循环随之退出并清理连接
+		_ = writeClose(user, "session revoked")
+		disConnect(user)
+	}
 }
 
 // 解析jwt
@@ -159,7 +218,7 @@ func parseJwt(r *http.Request) (*int64, error) {
 		return nil, errors.New("无权限访问")
 	}
 	// parts[1]是获取到的tokenString，我们使用之前定义好的解析JWT的函数来解析它
-	token, err := utils.ParseToken(parts[1])
+	token, err := utils.CheckToken(parts[1])
 	if err != nil {
 		return nil, errors.New("无权限访问")
 	}
@@ -178,7 +237,7 @@ func heatBeat(user *global.User) {
 		select {
 		case <-ticker.C:
 			// 每次定时器触发时，发送一个 WebSocket Ping 消息作为心跳
-			err := conn.WriteMessage(websocket.PingMessage, []byte("heartbeat"))
+			err := writeMessage(user, websocket.PingMessage, []byte("heartbeat"))
 			if err != nil {
 				log.Println(err) // 记录发送心跳消息时的错误
 				return           // 如果发送心跳消息失败，退出函数